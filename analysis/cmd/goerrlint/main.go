@@ -0,0 +1,16 @@
+// Command goerrlint runs analyzers that detect misuse of github.com/m-mizutani/goerr/v2.
+//
+// Usage:
+//
+//	goerrlint ./...
+//	go vet -vettool=$(which goerrlint) ./...
+package main
+
+import (
+	"github.com/m-mizutani/goerr/v2/analysis/goerrlint"
+	"golang.org/x/tools/go/analysis/multichecker"
+)
+
+func main() {
+	multichecker.Main(goerrlint.Analyzers...)
+}
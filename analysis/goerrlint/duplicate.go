@@ -0,0 +1,118 @@
+package goerrlint
+
+import (
+	"go/ast"
+	"go/constant"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// DuplicateAnalyzer reports duplicate string literals of goerr.ID, goerr.Sentinel and goerr.NewTag. Duplicates are detected within a package and against all packages it imports, directly or indirectly. Duplicates between packages that do not import each other, e.g. two sibling packages of the same module, are not detected because analysis facts only flow along import edges. Generated files are ignored because generated constructors repeat the ID of their sentinel error by design, and test files are ignored because tests often declare ad hoc IDs and tags.
+var DuplicateAnalyzer = &analysis.Analyzer{
+	Name:      "goerrduplicate",
+	Doc:       "report duplicate error IDs of goerr.ID and goerr.Sentinel, and duplicate tag names of goerr.NewTag\n\nDuplicates are detected within a package and against the packages it imports, directly or indirectly. Duplicates between packages that do not import each other are not detected.",
+	Requires:  []*analysis.Analyzer{inspect.Analyzer},
+	Run:       runDuplicate,
+	FactTypes: []analysis.Fact{new(declaredFact)},
+}
+
+// declaredFact is error IDs and tag names declared in a package. The value of map is position of the declaration.
+type declaredFact struct {
+	IDs  map[string]string
+	Tags map[string]string
+}
+
+func (*declaredFact) AFact() {}
+
+func (x *declaredFact) String() string {
+	ids := make([]string, 0, len(x.IDs))
+	for id := range x.IDs {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	tags := make([]string, 0, len(x.Tags))
+	for t := range x.Tags {
+		tags = append(tags, t)
+	}
+	sort.Strings(tags)
+
+	return "ids=[" + strings.Join(ids, " ") + "] tags=[" + strings.Join(tags, " ") + "]"
+}
+
+func runDuplicate(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	// Collect declarations of imported packages
+	imported := &declaredFact{IDs: map[string]string{}, Tags: map[string]string{}}
+	for _, f := range pass.AllPackageFacts() {
+		fact, ok := f.Fact.(*declaredFact)
+		if !ok || f.Package == pass.Pkg {
+			continue
+		}
+		for id, pos := range fact.IDs {
+			imported.IDs[id] = pos
+		}
+		for t, pos := range fact.Tags {
+			imported.Tags[t] = pos
+		}
+	}
+
+	own := &declaredFact{IDs: map[string]string{}, Tags: map[string]string{}}
+
+	ignored := map[string]bool{}
+	for _, file := range pass.Files {
+		name := pass.Fset.File(file.Pos()).Name()
+		if ast.IsGenerated(file) || strings.HasSuffix(name, "_test.go") {
+			ignored[name] = true
+		}
+	}
+
+	nodeFilter := []ast.Node{(*ast.CallExpr)(nil)}
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		call := n.(*ast.CallExpr)
+		if len(call.Args) == 0 || ignored[pass.Fset.File(call.Pos()).Name()] {
+			return
+		}
+
+		var kind string
+		var ownMap, importedMap map[string]string
+		switch {
+		case isGoerrFunc(pass.TypesInfo, call, "ID", "Sentinel"):
+			kind, ownMap, importedMap = "error ID", own.IDs, imported.IDs
+		case isGoerrFunc(pass.TypesInfo, call, "NewTag"):
+			kind, ownMap, importedMap = "tag name", own.Tags, imported.Tags
+		default:
+			return
+		}
+
+		tv, ok := pass.TypesInfo.Types[call.Args[0]]
+		if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+			return
+		}
+		value := constant.StringVal(tv.Value)
+		if value == "" {
+			return
+		}
+
+		pos := pass.Fset.Position(call.Args[0].Pos()).String()
+		if prev, ok := ownMap[value]; ok {
+			pass.Reportf(call.Args[0].Pos(), "duplicate %s %q, also declared at %s", kind, value, prev)
+			return
+		}
+		if prev, ok := importedMap[value]; ok {
+			pass.Reportf(call.Args[0].Pos(), "duplicate %s %q, also declared at %s", kind, value, prev)
+		}
+		ownMap[value] = pos
+	})
+
+	if len(own.IDs) > 0 || len(own.Tags) > 0 {
+		pass.ExportPackageFact(own)
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,113 @@
+package goerrlint
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// ErrorfAnalyzer reports fmt.Errorf that formats a goerr error, whose static type is *goerr.Error or *goerr.Errors, with a verb other than %w. The error chain is lost, so errors.Is, goerr.Values and stack trace of goerr can not reach the original error. Other errors are not reported because formatting them with %v is often a deliberate break of the chain.
+var ErrorfAnalyzer = &analysis.Analyzer{
+	Name:     "goerrerrorf",
+	Doc:      "report fmt.Errorf that formats a goerr error without %w and loses the error chain",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      runErrorf,
+}
+
+func runErrorf(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{(*ast.CallExpr)(nil)}
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		call := n.(*ast.CallExpr)
+		fn := calleeFunc(pass.TypesInfo, call)
+		if fn == nil || fn.FullName() != "fmt.Errorf" || len(call.Args) < 2 || call.Ellipsis.IsValid() {
+			return
+		}
+
+		lit, ok := call.Args[0].(*ast.BasicLit)
+		if !ok || lit.Kind != token.STRING {
+			return
+		}
+
+		for _, v := range parseVerbs(lit.Value) {
+			argIdx := v.arg + 1
+			if v.verb == 'w' || argIdx >= len(call.Args) {
+				continue
+			}
+			arg := call.Args[argIdx]
+			t := pass.TypesInfo.TypeOf(arg)
+			if t == nil || !isGoerrType(t, "Error", "Errors") {
+				continue
+			}
+
+			verbPos := lit.Pos() + token.Pos(v.offset)
+			pass.Report(analysis.Diagnostic{
+				Pos:     arg.Pos(),
+				End:     arg.End(),
+				Message: "fmt.Errorf formats error " + types.ExprString(arg) + " with %" + string(v.verb) + " and loses the error chain; use %w or goerr.Wrap",
+				SuggestedFixes: []analysis.SuggestedFix{
+					{
+						Message: "Use %w",
+						TextEdits: []analysis.TextEdit{
+							{Pos: verbPos, End: verbPos + 1, NewText: []byte("w")},
+						},
+					},
+				},
+			})
+		}
+	})
+
+	return nil, nil
+}
+
+// formatVerb is a verb in format string. arg is index of the argument, and offset is byte offset of the verb character in the source literal.
+type formatVerb struct {
+	verb   rune
+	arg    int
+	offset int
+}
+
+// parseVerbs returns verbs of fmt format string in source form (with quotes). It stops at explicit argument index such as %[1]v because the following arguments can not be tracked simply.
+func parseVerbs(src string) []formatVerb {
+	var verbs []formatVerb
+	arg := 0
+
+	for i := 0; i < len(src); i++ {
+		if src[i] != '%' {
+			continue
+		}
+		i++
+		if i >= len(src) {
+			break
+		}
+		if src[i] == '%' {
+			continue
+		}
+
+		// flags
+		for i < len(src) && strings.IndexByte("+-# 0", src[i]) >= 0 {
+			i++
+		}
+		// width and precision
+		for i < len(src) && (src[i] >= '0' && src[i] <= '9' || src[i] == '.' || src[i] == '*') {
+			if src[i] == '*' {
+				arg++
+			}
+			i++
+		}
+		if i >= len(src) || src[i] == '[' {
+			break
+		}
+
+		verbs = append(verbs, formatVerb{verb: rune(src[i]), arg: arg, offset: i})
+		arg++
+	}
+
+	return verbs
+}
@@ -0,0 +1,91 @@
+// Package goerrlint provides go/analysis analyzers that detect misuse of github.com/m-mizutani/goerr/v2.
+//
+// The analyzers can be run by cmd/goerrlint, either standalone or via `go vet -vettool=$(which goerrlint)`.
+package goerrlint
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+const goerrPkgPath = "github.com/m-mizutani/goerr/v2"
+
+// Analyzers is a list of all analyzers in goerrlint.
+var Analyzers = []*analysis.Analyzer{
+	TypedNilAnalyzer,
+	ErrorfAnalyzer,
+	UnusedAnalyzer,
+	NewInCheckAnalyzer,
+	DuplicateAnalyzer,
+}
+
+// isGoerrType returns true if t is pointer of named type in goerr package, e.g. isGoerrType(t, "Error") for *goerr.Error.
+func isGoerrType(t types.Type, names ...string) bool {
+	ptr, ok := t.(*types.Pointer)
+	if !ok {
+		return false
+	}
+	named, ok := ptr.Elem().(*types.Named)
+	if !ok {
+		return false
+	}
+	obj := named.Obj()
+	if obj.Pkg() == nil || obj.Pkg().Path() != goerrPkgPath {
+		return false
+	}
+	for _, name := range names {
+		if obj.Name() == name {
+			return true
+		}
+	}
+	return false
+}
+
+// calleeFunc returns the function or method called by call. It returns nil for calls of function values, conversions and builtins.
+func calleeFunc(info *types.Info, call *ast.CallExpr) *types.Func {
+	var ident *ast.Ident
+	switch fn := astutil.Unparen(call.Fun).(type) {
+	case *ast.Ident:
+		ident = fn
+	case *ast.SelectorExpr:
+		ident = fn.Sel
+	case *ast.IndexExpr:
+		return calleeFunc(info, &ast.CallExpr{Fun: fn.X})
+	default:
+		return nil
+	}
+
+	f, _ := info.Uses[ident].(*types.Func)
+	return f
+}
+
+// isGoerrFunc returns true if call is a call of one of the goerr functions or methods. Names are same as types.Func.FullName without the package path, e.g. "New" or "(*Error).Wrap".
+func isGoerrFunc(info *types.Info, call *ast.CallExpr, names ...string) bool {
+	fn := calleeFunc(info, call)
+	if fn == nil || fn.Pkg() == nil || fn.Pkg().Path() != goerrPkgPath {
+		return false
+	}
+
+	name := fn.Name()
+	if sig, ok := fn.Type().(*types.Signature); ok && sig.Recv() != nil {
+		recv := sig.Recv().Type()
+		prefix := ""
+		if ptr, ok := recv.(*types.Pointer); ok {
+			recv = ptr.Elem()
+			prefix = "*"
+		}
+		if named, ok := recv.(*types.Named); ok {
+			name = "(" + prefix + named.Obj().Name() + ")." + name
+		}
+	}
+
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,28 @@
+package goerrlint_test
+
+import (
+	"testing"
+
+	"github.com/m-mizutani/goerr/v2/analysis/goerrlint"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestTypedNilAnalyzer(t *testing.T) {
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), goerrlint.TypedNilAnalyzer, "typednil")
+}
+
+func TestErrorfAnalyzer(t *testing.T) {
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), goerrlint.ErrorfAnalyzer, "errorf")
+}
+
+func TestUnusedAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), goerrlint.UnusedAnalyzer, "unused")
+}
+
+func TestNewInCheckAnalyzer(t *testing.T) {
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), goerrlint.NewInCheckAnalyzer, "newincheck")
+}
+
+func TestDuplicateAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), goerrlint.DuplicateAnalyzer, "dupb", "dupa")
+}
@@ -0,0 +1,104 @@
+package goerrlint
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// NewInCheckAnalyzer reports goerr.New called in `if err != nil { ... }` block. The checked error should be wrapped by goerr.Wrap to keep the cause.
+var NewInCheckAnalyzer = &analysis.Analyzer{
+	Name:     "goerrnewincheck",
+	Doc:      "report goerr.New in `if err != nil` block that should be goerr.Wrap to keep the cause",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      runNewInCheck,
+}
+
+func runNewInCheck(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{(*ast.CallExpr)(nil)}
+	inspect.WithStack(nodeFilter, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+		call := n.(*ast.CallExpr)
+		if !isGoerrFunc(pass.TypesInfo, call, "New", "(*Builder).New") {
+			return true
+		}
+
+		checked := checkedError(pass, stack)
+		if checked == nil || usesObject(pass, call, checked) {
+			return true
+		}
+
+		fix := analysis.SuggestedFix{Message: "Use Wrap with " + checked.Name()}
+		if sel, ok := astutil.Unparen(call.Fun).(*ast.SelectorExpr); ok && len(call.Args) > 0 {
+			fix.TextEdits = []analysis.TextEdit{
+				{Pos: sel.Sel.Pos(), End: sel.Sel.End(), NewText: []byte("Wrap")},
+				{Pos: call.Args[0].Pos(), End: call.Args[0].Pos(), NewText: []byte(checked.Name() + ", ")},
+			}
+		}
+
+		diag := analysis.Diagnostic{
+			Pos:     call.Pos(),
+			End:     call.End(),
+			Message: "goerr New is called in `if " + checked.Name() + " != nil` block and discards " + checked.Name() + "; use Wrap(" + checked.Name() + ", ...) to keep the cause",
+		}
+		if len(fix.TextEdits) > 0 {
+			diag.SuggestedFixes = []analysis.SuggestedFix{fix}
+		}
+		pass.Report(diag)
+		return true
+	})
+
+	return nil, nil
+}
+
+// checkedError returns the error variable checked by the innermost enclosing `if x != nil` whose body contains the node. It does not cross function boundary.
+func checkedError(pass *analysis.Pass, stack []ast.Node) *types.Var {
+	for i := len(stack) - 1; i > 0; i-- {
+		switch parent := stack[i-1].(type) {
+		case *ast.FuncDecl, *ast.FuncLit:
+			return nil
+
+		case *ast.IfStmt:
+			if parent.Body != stack[i] {
+				continue
+			}
+			bin, ok := astutil.Unparen(parent.Cond).(*ast.BinaryExpr)
+			if !ok || bin.Op != token.NEQ {
+				continue
+			}
+			for _, side := range []ast.Expr{bin.X, bin.Y} {
+				ident, ok := astutil.Unparen(side).(*ast.Ident)
+				if !ok {
+					continue
+				}
+				v, ok := pass.TypesInfo.Uses[ident].(*types.Var)
+				if ok && types.Implements(v.Type(), errorIface) {
+					return v
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// usesObject returns true if obj is referred in node.
+func usesObject(pass *analysis.Pass, node ast.Node, obj types.Object) bool {
+	found := false
+	ast.Inspect(node, func(n ast.Node) bool {
+		if ident, ok := n.(*ast.Ident); ok && pass.TypesInfo.Uses[ident] == obj {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
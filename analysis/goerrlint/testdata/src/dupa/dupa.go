@@ -0,0 +1,14 @@
+package dupa // want package:`ids=\[not_found\] tags=\[retry unique\]`
+
+import (
+	"github.com/m-mizutani/goerr/v2"
+
+	"dupb"
+)
+
+var (
+	ErrNotFound = goerr.New("not found", goerr.ID("not_found")) // want `duplicate error ID "not_found", also declared at .*dupb.go:6:`
+	TagRetry    = goerr.NewTag("retry")                         // want `duplicate tag name "retry", also declared at .*dupb.go:7:`
+	TagUnique   = goerr.NewTag("unique")
+	_           = dupb.ErrNotFound
+)
@@ -0,0 +1,12 @@
+package dupb // want package:`ids=\[conflict not_found\] tags=\[retry\]`
+
+import "github.com/m-mizutani/goerr/v2"
+
+var (
+	ErrNotFound = goerr.Sentinel("not_found", "not found")
+	TagRetry    = goerr.NewTag("retry")
+)
+
+var ErrConflict = goerr.New("conflict", goerr.ID("conflict"))
+
+var ErrDuplicated = goerr.New("duplicated", goerr.ID("conflict")) // want `duplicate error ID "conflict", also declared at .*dupb.go:10:`
@@ -0,0 +1,46 @@
+package errorf
+
+import (
+	"fmt"
+
+	"github.com/m-mizutani/goerr/v2"
+)
+
+func wrapWithV(err *goerr.Error) error {
+	return fmt.Errorf("failed: %v", err) // want `fmt.Errorf formats error err with %v and loses the error chain`
+}
+
+func goerrWithS(id string) error {
+	goErr := goerr.New("not found")
+	return fmt.Errorf("user %s: %s", id, goErr) // want `fmt.Errorf formats error goErr with %s`
+}
+
+func widthAndFlags(err *goerr.Error) error {
+	return fmt.Errorf("%*d %+v", 3, 1, err) // want `formats error err with %v`
+}
+
+func joined(errs *goerr.Errors) error {
+	return fmt.Errorf("failed: %v", errs) // want `formats error errs with %v`
+}
+
+func constructor() error {
+	return fmt.Errorf("failed: %v", goerr.New("x")) // want `formats error goerr.New\("x"\) with %v`
+}
+
+// Cases below must not be reported
+
+func wrapWithW(err *goerr.Error) error {
+	return fmt.Errorf("failed: %w", err)
+}
+
+func notError(id string) error {
+	return fmt.Errorf("%% user %s", id)
+}
+
+func indexed(err *goerr.Error) error {
+	return fmt.Errorf("%[1]v", err)
+}
+
+func plainError(err error) error {
+	return fmt.Errorf("failed: %v", err)
+}
@@ -0,0 +1,34 @@
+// Package goerr is a stub of github.com/m-mizutani/goerr/v2 for analysistest.
+package goerr
+
+type Option func(*Error)
+
+type Error struct{ msg string }
+
+func (x *Error) Error() string                           { return x.msg }
+func (x *Error) ErrorOrNil() error                       { return x }
+func (x *Error) Wrap(cause error, opts ...Option) *Error { return x }
+
+type Errors struct{ errs []error }
+
+func (x *Errors) Error() string     { return "" }
+func (x *Errors) ErrorOrNil() error { return x }
+
+type Builder struct{}
+
+func (x *Builder) New(msg string, opts ...Option) *Error { return &Error{msg: msg} }
+
+func New(msg string, opts ...Option) *Error               { return &Error{msg: msg} }
+func Wrap(cause error, msg string, opts ...Option) *Error { return &Error{msg: msg} }
+func With(err error, opts ...Option) *Error               { return nil }
+func Unwrap(err error) *Error                             { return nil }
+func Join(errs ...error) *Errors                          { return nil }
+func Append(base *Errors, errs ...error) *Errors          { return base }
+
+type tag struct{ value string }
+
+func ID(id string) Option                                              { return nil }
+func V(key string, value any) Option                                   { return nil }
+func NewTag(value string) tag                                          { return tag{value: value} }
+func Sentinel(id, msg string, opts ...Option) *Error                   { return &Error{msg: msg} }
+func (x *Builder) Wrap(cause error, msg string, opts ...Option) *Error { return &Error{msg: msg} }
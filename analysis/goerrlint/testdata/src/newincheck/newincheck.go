@@ -0,0 +1,56 @@
+package newincheck
+
+import (
+	"os"
+
+	"github.com/m-mizutani/goerr/v2"
+)
+
+func open(path string) error {
+	if _, err := os.Stat(path); err != nil {
+		return goerr.New("failed to stat", goerr.V("path", path)) // want `goerr New is called in .if err != nil. block and discards err; use Wrap\(err, ...\) to keep the cause`
+	}
+	return nil
+}
+
+func builder(b *goerr.Builder, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		if path != "" {
+			return b.New("failed to open") // want `goerr New is called in .if err != nil. block`
+		}
+	}
+	_ = f
+	return nil
+}
+
+// Cases below must not be reported
+
+func wrapped(path string) error {
+	if _, err := os.Stat(path); err != nil {
+		return goerr.Wrap(err, "failed to stat")
+	}
+	return nil
+}
+
+func usesErr(path string) error {
+	if _, err := os.Stat(path); err != nil {
+		return goerr.New("failed to stat", goerr.V("error", err))
+	}
+	return nil
+}
+
+func closure(path string) error {
+	if _, err := os.Stat(path); err != nil {
+		fn := func() error { return goerr.New("independent") }
+		return fn()
+	}
+	return nil
+}
+
+func notNilCheck(path string) error {
+	if path == "" {
+		return goerr.New("empty path")
+	}
+	return nil
+}
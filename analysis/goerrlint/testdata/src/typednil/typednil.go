@@ -0,0 +1,109 @@
+package typednil
+
+import (
+	"errors"
+
+	"github.com/m-mizutani/goerr/v2"
+)
+
+func joinDirect(a, b error) error {
+	return goerr.Join(a, b) // want `returning goerr.Join\(a, b\) of type \*goerr.Errors as error may cause typed-nil problem`
+}
+
+func appendVar(items []string) error {
+	var errs *goerr.Errors
+	for _, item := range items {
+		if item == "" {
+			errs = goerr.Append(errs, errors.New("empty"))
+		}
+	}
+	return errs // want `returning errs of type \*goerr.Errors as error`
+}
+
+func errorVar(fail bool) error {
+	var err *goerr.Error
+	if fail {
+		err = goerr.New("failed")
+	}
+	return err // want `returning err of type \*goerr.Error as error`
+}
+
+func withResult(cause error) (int, error) {
+	return 0, goerr.With(cause) // want `returning goerr.With\(cause\) of type \*goerr.Error as error`
+}
+
+type holder struct {
+	err *goerr.Error
+}
+
+func (h *holder) get() error {
+	return h.err // want `returning h.err of type \*goerr.Error as error`
+}
+
+func inClosure() func() error {
+	return func() error {
+		var errs *goerr.Errors
+		return errs // want `returning errs of type \*goerr.Errors as error`
+	}
+}
+
+// Cases below must not be reported
+
+func useErrorOrNil(a, b error) error {
+	return goerr.Join(a, b).ErrorOrNil()
+}
+
+func constructors(cause error, b *goerr.Builder) error {
+	if cause != nil {
+		return goerr.Wrap(cause, "failed")
+	}
+	return goerr.New("failed")
+}
+
+func definedOnce() error {
+	err := goerr.New("failed")
+	return err
+}
+
+func builderOnce(b *goerr.Builder) error {
+	err := b.New("failed")
+	return err
+}
+
+func guardedByIf(errs *goerr.Errors) error {
+	if errs != nil {
+		return errs
+	}
+	return nil
+}
+
+func guardedByEarlyReturn(errs *goerr.Errors) error {
+	if errs == nil {
+		return nil
+	}
+	return errs
+}
+
+func guardedByAnd(err *goerr.Error, ok bool) error {
+	if ok && err != nil {
+		return err
+	}
+	return nil
+}
+
+func withGuarded(err error) error {
+	if err == nil {
+		return nil
+	}
+	return goerr.With(err)
+}
+
+func concreteResult() *goerr.Error {
+	var err *goerr.Error
+	return err
+}
+
+func interfaceVar() error {
+	var err error
+	return err
+}
@@ -0,0 +1,28 @@
+package unused
+
+import (
+	"errors"
+
+	"github.com/m-mizutani/goerr/v2"
+)
+
+func discard(err error, b *goerr.Builder) {
+	goerr.Wrap(err, "failed") // want `result of goerr.Wrap is not used`
+	goerr.New("failed")       // want `result of goerr.New is not used`
+	b.Wrap(err, "failed")     // want `result of b.Wrap is not used`
+
+	goerr.Append(nil, err) // want `result of goerr.Append is not used`
+}
+
+// Cases below must not be reported
+
+func used(err error) error {
+	_ = errors.New("unrelated")
+	wrapped := goerr.Wrap(err, "failed")
+	return wrapped
+}
+
+func appendInPlace(errs *goerr.Errors, err error) {
+	// Append to non-nil base updates it in place
+	goerr.Append(errs, err)
+}
@@ -0,0 +1,240 @@
+package goerrlint
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// TypedNilAnalyzer reports *goerr.Error and *goerr.Errors returned through an error result without ErrorOrNil. A nil pointer returned as error is not equal to nil (typed-nil problem).
+var TypedNilAnalyzer = &analysis.Analyzer{
+	Name:     "goerrtypednil",
+	Doc:      "report *goerr.Error and *goerr.Errors returned as error without ErrorOrNil, which may cause typed-nil problem",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      runTypedNil,
+}
+
+// mayReturnNil is goerr functions that return nil for nil or empty input.
+var mayReturnNil = []string{"Join", "Append", "With", "Unwrap", "AsErrors"}
+
+// neverReturnNil is goerr functions and methods that always return a non-nil value.
+var neverReturnNil = []string{
+	"New", "Wrap", "Sentinel",
+	"(*Error).Wrap", "(*Error).Unstack", "(*Error).UnstackN", "(*Error).WithTags",
+	"(*Builder).New", "(*Builder).Wrap",
+	"(*CatalogEntry).New", "(*CatalogEntry).Wrap",
+}
+
+var errorType = types.Universe.Lookup("error").Type()
+
+var errorIface = errorType.Underlying().(*types.Interface)
+
+func runTypedNil(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{(*ast.ReturnStmt)(nil)}
+	inspect.WithStack(nodeFilter, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+		ret := n.(*ast.ReturnStmt)
+
+		sig, body := enclosingFunc(pass.TypesInfo, stack)
+		if sig == nil || sig.Results().Len() != len(ret.Results) {
+			return true
+		}
+
+		for i, result := range ret.Results {
+			if !types.Identical(sig.Results().At(i).Type(), errorType) {
+				continue
+			}
+			if !isGoerrType(pass.TypesInfo.TypeOf(result), "Error", "Errors") {
+				continue
+			}
+			if mayBeNil(pass, result, stack, body) {
+				report(pass, result)
+			}
+		}
+		return true
+	})
+
+	return nil, nil
+}
+
+// enclosingFunc returns signature and body of the innermost function in stack.
+func enclosingFunc(info *types.Info, stack []ast.Node) (*types.Signature, *ast.BlockStmt) {
+	for i := len(stack) - 1; i >= 0; i-- {
+		switch fn := stack[i].(type) {
+		case *ast.FuncDecl:
+			if obj, ok := info.Defs[fn.Name].(*types.Func); ok {
+				return obj.Type().(*types.Signature), fn.Body
+			}
+			return nil, nil
+		case *ast.FuncLit:
+			sig, _ := info.TypeOf(fn).(*types.Signature)
+			return sig, fn.Body
+		}
+	}
+	return nil, nil
+}
+
+func mayBeNil(pass *analysis.Pass, expr ast.Expr, stack []ast.Node, body *ast.BlockStmt) bool {
+	expr = astutil.Unparen(expr)
+
+	switch e := expr.(type) {
+	case *ast.CallExpr:
+		if !isGoerrFunc(pass.TypesInfo, e, mayReturnNil...) {
+			return false
+		}
+		// With returns nil only if the error is nil
+		if isGoerrFunc(pass.TypesInfo, e, "With") && len(e.Args) > 0 && guardedByNilCheck(e.Args[0], stack) {
+			return false
+		}
+		return true
+
+	case *ast.Ident:
+		obj, ok := pass.TypesInfo.Uses[e].(*types.Var)
+		if !ok {
+			return false
+		}
+		if assignedOnlyNonNil(pass, obj, body) {
+			return false
+		}
+		return !guardedByNilCheck(expr, stack)
+
+	case *ast.SelectorExpr:
+		if _, ok := pass.TypesInfo.Uses[e.Sel].(*types.Var); !ok {
+			return false
+		}
+		return !guardedByNilCheck(expr, stack)
+	}
+
+	return false
+}
+
+// assignedOnlyNonNil returns true if the variable is assigned exactly once in body and the value is created by a goerr function that never returns nil, such as `err := goerr.New("...")`.
+func assignedOnlyNonNil(pass *analysis.Pass, obj *types.Var, body *ast.BlockStmt) bool {
+	var count int
+	var nonNil bool
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch stmt := n.(type) {
+		case *ast.AssignStmt:
+			for i, lhs := range stmt.Lhs {
+				ident, ok := astutil.Unparen(lhs).(*ast.Ident)
+				if !ok || (pass.TypesInfo.Defs[ident] != obj && pass.TypesInfo.Uses[ident] != obj) {
+					continue
+				}
+				count++
+				if len(stmt.Lhs) == len(stmt.Rhs) {
+					call, ok := astutil.Unparen(stmt.Rhs[i]).(*ast.CallExpr)
+					nonNil = ok && isGoerrFunc(pass.TypesInfo, call, neverReturnNil...)
+				}
+			}
+		case *ast.ValueSpec:
+			for i, name := range stmt.Names {
+				if pass.TypesInfo.Defs[name] != obj {
+					continue
+				}
+				count++
+				if i < len(stmt.Values) {
+					call, ok := astutil.Unparen(stmt.Values[i]).(*ast.CallExpr)
+					nonNil = ok && isGoerrFunc(pass.TypesInfo, call, neverReturnNil...)
+				}
+			}
+		case *ast.UnaryExpr:
+			// Taking address allows assignment via pointer
+			if stmt.Op == token.AND {
+				if ident, ok := astutil.Unparen(stmt.X).(*ast.Ident); ok && pass.TypesInfo.Uses[ident] == obj {
+					count++
+				}
+			}
+		}
+		return true
+	})
+
+	return count == 1 && nonNil
+}
+
+// guardedByNilCheck returns true if the return statement is in `if x != nil { ... }` block, or preceded by `if x == nil { return ... }` in an enclosing block.
+func guardedByNilCheck(expr ast.Expr, stack []ast.Node) bool {
+	target := types.ExprString(expr)
+
+	for i := len(stack) - 1; i > 0; i-- {
+		child := stack[i]
+		switch parent := stack[i-1].(type) {
+		case *ast.FuncDecl, *ast.FuncLit:
+			return false
+
+		case *ast.IfStmt:
+			if parent.Body == child && hasNilCheck(parent.Cond, target, token.NEQ) {
+				return true
+			}
+
+		case *ast.BlockStmt:
+			for _, stmt := range parent.List {
+				if stmt == child {
+					break
+				}
+				ifStmt, ok := stmt.(*ast.IfStmt)
+				if !ok || ifStmt.Else != nil || len(ifStmt.Body.List) == 0 {
+					continue
+				}
+				if _, ok := ifStmt.Body.List[len(ifStmt.Body.List)-1].(*ast.ReturnStmt); !ok {
+					continue
+				}
+				if hasNilCheck(ifStmt.Cond, target, token.EQL) {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+// hasNilCheck returns true if cond is `target <op> nil`. For token.NEQ, it also accepts the check in && chain. For token.EQL, it also accepts the check in || chain.
+func hasNilCheck(cond ast.Expr, target string, op token.Token) bool {
+	bin, ok := astutil.Unparen(cond).(*ast.BinaryExpr)
+	if !ok {
+		return false
+	}
+
+	switch bin.Op {
+	case token.LAND:
+		return op == token.NEQ && (hasNilCheck(bin.X, target, op) || hasNilCheck(bin.Y, target, op))
+	case token.LOR:
+		return op == token.EQL && (hasNilCheck(bin.X, target, op) || hasNilCheck(bin.Y, target, op))
+	case op:
+		x, y := types.ExprString(bin.X), types.ExprString(bin.Y)
+		return (x == target && y == "nil") || (x == "nil" && y == target)
+	}
+
+	return false
+}
+
+func report(pass *analysis.Pass, expr ast.Expr) {
+	name := types.ExprString(expr)
+	pass.Report(analysis.Diagnostic{
+		Pos:     expr.Pos(),
+		End:     expr.End(),
+		Message: "returning " + name + " of type " + types.TypeString(pass.TypesInfo.TypeOf(expr), shortQualifier) + " as error may cause typed-nil problem; use " + name + ".ErrorOrNil()",
+		SuggestedFixes: []analysis.SuggestedFix{
+			{
+				Message: "Use ErrorOrNil()",
+				TextEdits: []analysis.TextEdit{
+					{Pos: expr.End(), End: expr.End(), NewText: []byte(".ErrorOrNil()")},
+				},
+			},
+		},
+	})
+}
+
+func shortQualifier(pkg *types.Package) string {
+	return pkg.Name()
+}
@@ -0,0 +1,54 @@
+package goerrlint
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// UnusedAnalyzer reports calls of goerr functions whose error result is discarded, such as `goerr.Wrap(err, "failed")` without return or assignment.
+var UnusedAnalyzer = &analysis.Analyzer{
+	Name:     "goerrunused",
+	Doc:      "report goerr errors that are created but discarded",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      runUnused,
+}
+
+// errorConstructors is goerr functions and methods that create a new error without side effect. Append is not included because it appends errors to a non-nil base in place; it is reported only if the base is nil literal.
+var errorConstructors = []string{
+	"New", "Wrap", "With", "Join", "Sentinel", "NewCtx", "WrapCtx",
+	"(*Error).Wrap",
+	"(*Builder).New", "(*Builder).Wrap",
+	"(*CatalogEntry).New", "(*CatalogEntry).Wrap",
+}
+
+func runUnused(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{(*ast.ExprStmt)(nil)}
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		call, ok := astutil.Unparen(n.(*ast.ExprStmt).X).(*ast.CallExpr)
+		if !ok {
+			return
+		}
+		if !isGoerrFunc(pass.TypesInfo, call, errorConstructors...) && !isNilAppend(pass.TypesInfo, call) {
+			return
+		}
+
+		pass.Reportf(call.Pos(), "result of %s is not used; the created error is discarded", types.ExprString(call.Fun))
+	})
+
+	return nil, nil
+}
+
+// isNilAppend returns true if call is goerr.Append with nil literal as base, which creates a new *Errors.
+func isNilAppend(info *types.Info, call *ast.CallExpr) bool {
+	if len(call.Args) == 0 || !isGoerrFunc(info, call, "Append") {
+		return false
+	}
+	return info.Types[call.Args[0]].IsNil()
+}
@@ -1,6 +1,6 @@
 package goerr
 
-// Builder keeps a set of key-value pairs and can create a new error and wrap error with the key-value pairs.
+// Builder keeps a set of key-value pairs and can create a new error and wrap error with the key-value pairs. A Builder is never modified after creation, so it is safe to share between goroutines.
 type Builder struct {
 	options []Option
 }
@@ -15,7 +15,7 @@ type Builder struct {
 //   // Both errors will include service and request_id context
 func NewBuilder(options ...Option) *Builder {
 	return &Builder{
-		options: options,
+		options: append([]Option{}, options...),
 	}
 }
 
@@ -26,11 +26,16 @@ func NewBuilder(options ...Option) *Builder {
 //   userBuilder := baseBuilder.With(goerr.V("user_id", "user123"))
 //   err := userBuilder.New("access denied") // includes both service and user_id
 func (x *Builder) With(options ...Option) *Builder {
-	newBuilder := &Builder{
-		options: x.options[:],
+	return &Builder{
+		options: x.mergeOptions(options),
 	}
-	newBuilder.options = append(newBuilder.options, options...)
-	return newBuilder
+}
+
+// mergeOptions returns a new slice of the Builder options followed by options. It never appends to x.options directly because sibling Builders may share its backing array.
+func (x *Builder) mergeOptions(options []Option) []Option {
+	merged := make([]Option, 0, len(x.options)+len(options))
+	merged = append(merged, x.options...)
+	return append(merged, options...)
 }
 
 // New creates a new error with message
@@ -39,7 +44,7 @@ func (x *Builder) With(options ...Option) *Builder {
 //   builder := goerr.NewBuilder(goerr.V("service", "auth"))
 //   err := builder.New("authentication failed") // includes service context
 func (x *Builder) New(msg string, options ...Option) *Error {
-	err := newError(append(x.options, options...)...)
+	err := newError(x.mergeOptions(options)...)
 	err.msg = msg
 	return err
 }
@@ -50,8 +55,8 @@ func (x *Builder) New(msg string, options ...Option) *Error {
 //   builder := goerr.NewBuilder(goerr.V("service", "auth"))
 //   err := builder.Wrap(dbErr, "database query failed") // wraps dbErr with service context
 func (x *Builder) Wrap(cause error, msg string, options ...Option) *Error {
-	err := newError(append(x.options, options...)...)
+	err := newError(x.mergeOptions(options)...)
 	err.msg = msg
-	err.cause = cause
+	err.setCause(nil, cause)
 	return err
 }
@@ -33,6 +33,26 @@ func TestBuilderWrap(t *testing.T) {
 	}
 }
 
+func TestBuilderWithSiblings(t *testing.T) {
+	// Options slice with spare capacity can be shared by sibling builders if Builder appends to it directly
+	options := make([]goerr.Option, 0, 8)
+	options = append(options, goerr.V("service", "auth"))
+	base := goerr.NewBuilder(options...)
+
+	b1 := base.With(goerr.V("user", "alice"))
+	b2 := base.With(goerr.V("user", "bob"))
+
+	if v := b1.New("error").Values(); v["user"] != "alice" || v["service"] != "auth" {
+		t.Errorf("Unexpected values of b1: %v", v)
+	}
+	if v := b2.New("error").Values(); v["user"] != "bob" || v["service"] != "auth" {
+		t.Errorf("Unexpected values of b2: %v", v)
+	}
+	if v := base.New("error").Values(); len(v) != 1 {
+		t.Errorf("Base builder should not be modified: %v", v)
+	}
+}
+
 func ExampleNewBuilder() {
 	// Create a builder with common context for a request.
 	builder := goerr.NewBuilder(
@@ -0,0 +1,259 @@
+package goerr
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+var (
+	// ErrCatalogDuplicateID is returned by (*Catalog).Register when the ID is already registered.
+	ErrCatalogDuplicateID = Sentinel("goerr.catalog.duplicate_id", "duplicate error ID in catalog")
+
+	// ErrCatalogMissingKey is returned by (*CatalogEntry).Validate when a required typed value is not set.
+	ErrCatalogMissingKey = Sentinel("goerr.catalog.missing_key", "required typed value is missing")
+)
+
+// Catalog is a set of declarative error definitions. Each entry has an ID, a default message, tags and required typed keys. A Catalog can be used to list all errors of an application for documentation and API contracts.
+//
+// Usage:
+//
+//	var (
+//		catalog = goerr.NewCatalog()
+//
+//		UserIDKey       = goerr.NewTypedKey[string]("user_id")
+//		ErrUserNotFound = catalog.MustRegister("user_not_found", "user not found",
+//			goerr.EntryTags(TagNotFound), goerr.EntryRequires(UserIDKey))
+//	)
+//
+//	func FindUser(id string) (*User, error) {
+//		...
+//		return nil, ErrUserNotFound.Wrap(sql.ErrNoRows, goerr.TV(UserIDKey, id))
+//	}
+type Catalog struct {
+	mu      sync.RWMutex
+	entries []*CatalogEntry
+	index   map[string]*CatalogEntry
+	devMode bool
+}
+
+// CatalogOption is an option for NewCatalog.
+type CatalogOption func(*Catalog)
+
+// DevMode enables development mode of Catalog. In development mode, (*CatalogEntry).New and (*CatalogEntry).Wrap panic if a required typed value is missing. It helps to find incomplete errors in tests.
+func DevMode(enabled bool) CatalogOption {
+	return func(c *Catalog) {
+		c.devMode = enabled
+	}
+}
+
+// NewCatalog creates a new empty Catalog.
+func NewCatalog(options ...CatalogOption) *Catalog {
+	c := &Catalog{
+		index: make(map[string]*CatalogEntry),
+	}
+	for _, opt := range options {
+		opt(c)
+	}
+	return c
+}
+
+// namedKey is a key that has a name. TypedKey satisfies it regardless of its type parameter.
+type namedKey interface {
+	Name() string
+}
+
+// EntryOption is an option for (*Catalog).Register.
+type EntryOption func(*CatalogEntry)
+
+// EntryTags sets tags that are attached to all errors of the entry.
+func EntryTags(tags ...tag) EntryOption {
+	return func(e *CatalogEntry) {
+		e.tags = append(e.tags, tags...)
+	}
+}
+
+// EntryRequires sets typed keys that must be set to errors of the entry.
+func EntryRequires(keys ...namedKey) EntryOption {
+	return func(e *CatalogEntry) {
+		for _, key := range keys {
+			e.required = append(e.required, key.Name())
+		}
+	}
+}
+
+// EntryDescription sets description of the entry for documentation.
+func EntryDescription(desc string) EntryOption {
+	return func(e *CatalogEntry) {
+		e.description = desc
+	}
+}
+
+// Register adds a new entry to the catalog. It returns ErrCatalogDuplicateID if the ID is already registered.
+func (x *Catalog) Register(id, msg string, options ...EntryOption) (*CatalogEntry, error) {
+	entry := &CatalogEntry{
+		id:      id,
+		message: msg,
+		catalog: x,
+	}
+	for _, opt := range options {
+		opt(entry)
+	}
+
+	sentinelOptions := make([]Option, 0, len(entry.tags))
+	for _, t := range entry.tags {
+		sentinelOptions = append(sentinelOptions, Tag(t))
+	}
+	entry.sentinel = Sentinel(id, msg, sentinelOptions...)
+
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	if _, ok := x.index[id]; ok {
+		return nil, ErrCatalogDuplicateID.Wrap(nil, V("id", id))
+	}
+	x.index[id] = entry
+	x.entries = append(x.entries, entry)
+
+	return entry, nil
+}
+
+// MustRegister is same as Register, but panics if the ID is already registered. It is useful to define entries as package-level variables.
+func (x *Catalog) MustRegister(id, msg string, options ...EntryOption) *CatalogEntry {
+	entry, err := x.Register(id, msg, options...)
+	if err != nil {
+		panic(err)
+	}
+	return entry
+}
+
+// Lookup returns the entry of the ID. If not found, it returns nil.
+func (x *Catalog) Lookup(id string) *CatalogEntry {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	return x.index[id]
+}
+
+// Entries returns all entries in registration order.
+func (x *Catalog) Entries() []*CatalogEntry {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+
+	entries := make([]*CatalogEntry, len(x.entries))
+	copy(entries, x.entries)
+	return entries
+}
+
+// MarshalJSON implements json.Marshaler interface. It exports all entries as a JSON array.
+func (x *Catalog) MarshalJSON() ([]byte, error) {
+	return json.Marshal(x.Entries())
+}
+
+// CatalogEntry is an error definition in Catalog. It creates errors with its ID, message and tags.
+type CatalogEntry struct {
+	id          string
+	message     string
+	description string
+	tags        []tag
+	required    []string
+	sentinel    *Error
+	catalog     *Catalog
+}
+
+// ID returns ID of the entry.
+func (x *CatalogEntry) ID() string { return x.id }
+
+// Message returns default message of the entry.
+func (x *CatalogEntry) Message() string { return x.message }
+
+// Description returns description of the entry.
+func (x *CatalogEntry) Description() string { return x.description }
+
+// Tags returns tag names of the entry.
+func (x *CatalogEntry) Tags() []string {
+	tagList := make([]string, 0, len(x.tags))
+	for _, t := range x.tags {
+		tagList = append(tagList, t.value)
+	}
+	return tagList
+}
+
+// RequiredKeys returns names of the required typed keys of the entry.
+func (x *CatalogEntry) RequiredKeys() []string {
+	keys := make([]string, len(x.required))
+	copy(keys, x.required)
+	return keys
+}
+
+// Sentinel returns the sentinel error of the entry. All errors created by the entry match it with errors.Is.
+func (x *CatalogEntry) Sentinel() *Error {
+	return x.sentinel
+}
+
+// Is returns true if err is created by the entry.
+func (x *CatalogEntry) Is(err error) bool {
+	return errors.Is(err, x.sentinel)
+}
+
+// New creates a new error of the entry with the default message.
+func (x *CatalogEntry) New(options ...Option) *Error {
+	err := newError()
+	x.sentinel.copy(err, options...)
+	x.check(err)
+	return err
+}
+
+// Wrap creates a new error of the entry with the default message and cause.
+func (x *CatalogEntry) Wrap(cause error, options ...Option) *Error {
+	err := newError()
+	x.sentinel.copy(err, options...)
+	err.setCause(nil, cause)
+	x.check(err)
+	return err
+}
+
+// Validate returns ErrCatalogMissingKey if a required typed value of the entry is not set in err or its wrapped errors.
+func (x *CatalogEntry) Validate(err error) error {
+	typedValues := TypedValues(err)
+
+	var missing []string
+	for _, key := range x.required {
+		if _, ok := typedValues[key]; !ok {
+			missing = append(missing, key)
+		}
+	}
+
+	if len(missing) > 0 {
+		return ErrCatalogMissingKey.Wrap(nil, V("id", x.id), V("missing", missing))
+	}
+	return nil
+}
+
+func (x *CatalogEntry) check(err *Error) {
+	if x.catalog == nil || !x.catalog.devMode {
+		return
+	}
+	if vErr := x.Validate(err); vErr != nil {
+		panic(fmt.Sprintf("goerr: %s: %v", vErr.Error(), Values(vErr)))
+	}
+}
+
+type catalogEntryJSON struct {
+	ID           string   `json:"id"`
+	Message      string   `json:"message"`
+	Description  string   `json:"description,omitempty"`
+	Tags         []string `json:"tags"`
+	RequiredKeys []string `json:"required_keys"`
+}
+
+// MarshalJSON implements json.Marshaler interface.
+func (x *CatalogEntry) MarshalJSON() ([]byte, error) {
+	return json.Marshal(catalogEntryJSON{
+		ID:           x.id,
+		Message:      x.message,
+		Description:  x.description,
+		Tags:         x.Tags(),
+		RequiredKeys: x.RequiredKeys(),
+	})
+}
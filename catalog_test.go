@@ -0,0 +1,137 @@
+package goerr_test
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/m-mizutani/goerr/v2"
+)
+
+func TestCatalog(t *testing.T) {
+	tagNotFound := goerr.NewTag("not_found")
+	userIDKey := goerr.NewTypedKey[string]("user_id")
+
+	catalog := goerr.NewCatalog()
+	entry := catalog.MustRegister("user_not_found", "user not found",
+		goerr.EntryTags(tagNotFound),
+		goerr.EntryRequires(userIDKey),
+		goerr.EntryDescription("The user does not exist"),
+	)
+
+	err := entry.New(goerr.TV(userIDKey, "u1"))
+	if err.Error() != "user not found" {
+		t.Errorf("Unexpected message: %s", err.Error())
+	}
+	if !err.HasTag(tagNotFound) {
+		t.Error("Expected tag of entry")
+	}
+	if !entry.Is(err) || !errors.Is(err, entry.Sentinel()) {
+		t.Error("Expected to match entry")
+	}
+	if stacks := err.Stacks(); len(stacks) == 0 || !strings.HasSuffix(stacks[0].Func, "TestCatalog") {
+		t.Errorf("Expected stack trace of call site: %v", stacks)
+	}
+	if entry.Validate(err) != nil {
+		t.Error("Expected valid error")
+	}
+
+	cause := errors.New("no rows")
+	wrapped := entry.Wrap(cause, goerr.TV(userIDKey, "u2"))
+	if wrapped.Error() != "user not found: no rows" || !errors.Is(wrapped, cause) || !entry.Is(wrapped) {
+		t.Errorf("Unexpected wrapped error: %v", wrapped)
+	}
+
+	if catalog.Lookup("user_not_found") != entry || catalog.Lookup("unknown") != nil {
+		t.Error("Unexpected lookup result")
+	}
+}
+
+func TestCatalogDuplicateID(t *testing.T) {
+	catalog := goerr.NewCatalog()
+	if _, err := catalog.Register("dup", "first"); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := catalog.Register("dup", "second")
+	if !errors.Is(err, goerr.ErrCatalogDuplicateID) {
+		t.Errorf("Expected ErrCatalogDuplicateID, got %v", err)
+	}
+	if goerr.Values(err)["id"] != "dup" {
+		t.Errorf("Expected id value: %v", goerr.Values(err))
+	}
+	if len(catalog.Entries()) != 1 {
+		t.Error("Duplicate entry should not be registered")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("MustRegister should panic")
+		}
+	}()
+	catalog.MustRegister("dup", "third")
+}
+
+func TestCatalogRequiredKeys(t *testing.T) {
+	userIDKey := goerr.NewTypedKey[string]("user_id")
+	countKey := goerr.NewTypedKey[int]("count")
+
+	entry := goerr.NewCatalog().MustRegister("e", "error", goerr.EntryRequires(userIDKey, countKey))
+
+	err := entry.New(goerr.TV(userIDKey, "u1"))
+	vErr := entry.Validate(err)
+	if !errors.Is(vErr, goerr.ErrCatalogMissingKey) {
+		t.Fatalf("Expected ErrCatalogMissingKey, got %v", vErr)
+	}
+	if missing, ok := goerr.Values(vErr)["missing"].([]string); !ok || len(missing) != 1 || missing[0] != "count" {
+		t.Errorf("Unexpected missing keys: %v", goerr.Values(vErr))
+	}
+
+	// Required key can be supplied by wrapped error
+	inner := goerr.New("inner", goerr.TV(countKey, 1))
+	if vErr := entry.Validate(entry.Wrap(inner, goerr.TV(userIDKey, "u1"))); vErr != nil {
+		t.Errorf("Unexpected error: %v", vErr)
+	}
+}
+
+func TestCatalogDevMode(t *testing.T) {
+	userIDKey := goerr.NewTypedKey[string]("user_id")
+	entry := goerr.NewCatalog(goerr.DevMode(true)).MustRegister("e", "error", goerr.EntryRequires(userIDKey))
+
+	_ = entry.New(goerr.TV(userIDKey, "u1"))
+
+	defer func() {
+		if r := recover(); r == nil || !strings.Contains(r.(string), "user_id") {
+			t.Errorf("Expected panic with missing key, got %v", r)
+		}
+	}()
+	_ = entry.New()
+}
+
+func TestCatalogEntries(t *testing.T) {
+	tag := goerr.NewTag("t1")
+	key := goerr.NewTypedKey[string]("k1")
+
+	catalog := goerr.NewCatalog()
+	catalog.MustRegister("b", "error b", goerr.EntryTags(tag), goerr.EntryRequires(key), goerr.EntryDescription("desc"))
+	catalog.MustRegister("a", "error a")
+
+	entries := catalog.Entries()
+	if len(entries) != 2 || entries[0].ID() != "b" || entries[1].ID() != "a" {
+		t.Fatalf("Entries should be in registration order: %v", entries)
+	}
+	if entries[0].Message() != "error b" || entries[0].Description() != "desc" ||
+		entries[0].Tags()[0] != "t1" || entries[0].RequiredKeys()[0] != "k1" {
+		t.Error("Unexpected entry attributes")
+	}
+
+	data, err := json.Marshal(catalog)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `[{"id":"b","message":"error b","description":"desc","tags":["t1"],"required_keys":["k1"]},{"id":"a","message":"error a","tags":[],"required_keys":[]}]`
+	if string(data) != expected {
+		t.Errorf("Unexpected JSON:\n%s", string(data))
+	}
+}
@@ -0,0 +1,60 @@
+package goerr
+
+import (
+	"fmt"
+	"log/slog"
+	"sort"
+)
+
+// PrintableCause is printable object of a cause that is not *goerr.Error. Type is Go type name of the cause (e.g. "*fs.PathError") and it can be used as `exception.type` of OpenTelemetry. Fields has structured fields extracted by enrichers, see RegisterEnricher.
+type PrintableCause struct {
+	Message string         `json:"message"`
+	Type    string         `json:"type"`
+	Fields  map[string]any `json:"fields,omitempty"`
+}
+
+func newPrintableCause(err error) *PrintableCause {
+	return &PrintableCause{
+		Message: err.Error(),
+		Type:    typeName(err),
+		Fields:  causeFields(err),
+	}
+}
+
+// typeName returns Go type name of err such as "*goerr.Error" or "*fs.PathError".
+func typeName(err error) string {
+	return fmt.Sprintf("%T", err)
+}
+
+// causeFields extracts structured fields from err by registered enrichers. It returns nil if no enricher supports err.
+func causeFields(err error) map[string]any {
+	extracted := extract(err)
+	if len(extracted.values) == 0 {
+		return nil
+	}
+	return extracted.values
+}
+
+// causeLogValue returns slog.Value of a cause that is not *goerr.Error.
+func causeLogValue(err error) slog.Value {
+	attrs := []slog.Attr{
+		slog.String("message", err.Error()),
+		slog.String("type", typeName(err)),
+	}
+
+	if fields := causeFields(err); len(fields) > 0 {
+		keys := make([]string, 0, len(fields))
+		for k := range fields {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		var fieldAttrs []any
+		for _, k := range keys {
+			fieldAttrs = append(fieldAttrs, slog.Any(k, fields[k]))
+		}
+		attrs = append(attrs, slog.Group("fields", fieldAttrs...))
+	}
+
+	return slog.GroupValue(attrs...)
+}
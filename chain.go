@@ -0,0 +1,166 @@
+package goerr
+
+import (
+	"reflect"
+	"sync/atomic"
+)
+
+// DefaultMaxChainDepth is default maximum number of errors visited when goerr traverses an error chain.
+const DefaultMaxChainDepth = 1024
+
+var maxChainDepth atomic.Int64
+
+func init() {
+	maxChainDepth.Store(DefaultMaxChainDepth)
+}
+
+// SetMaxChainDepth sets maximum number of errors visited when goerr traverses an error chain by Unwrap, e.g. merging values and tags or building Printable. Errors deeper than the limit are ignored. If n is zero or negative, DefaultMaxChainDepth is used.
+//
+// Usage:
+//
+//	goerr.SetMaxChainDepth(64)
+func SetMaxChainDepth(n int) {
+	if n <= 0 {
+		n = DefaultMaxChainDepth
+	}
+	maxChainDepth.Store(int64(n))
+}
+
+// MaxChainDepth returns current maximum number of errors visited when goerr traverses an error chain.
+func MaxChainDepth() int {
+	return int(maxChainDepth.Load())
+}
+
+// walker traverses an error chain iteratively. It stops at an error that has already been visited (cyclic Unwrap) or when number of visited errors reaches MaxChainDepth.
+type walker struct {
+	visited map[error]struct{}
+	count   int
+	limit   int
+}
+
+func newWalker() *walker {
+	return &walker{
+		visited: make(map[error]struct{}),
+		limit:   MaxChainDepth(),
+	}
+}
+
+// enter marks err as visited. It returns false if err must not be visited.
+func (w *walker) enter(err error) bool {
+	if w.count >= w.limit {
+		return false
+	}
+	w.count++
+
+	// Only pointer errors are tracked because other types may not be comparable. Non-pointer cycles are stopped by the limit.
+	if reflect.TypeOf(err).Kind() == reflect.Pointer {
+		if _, ok := w.visited[err]; ok {
+			return false
+		}
+		w.visited[err] = struct{}{}
+	}
+
+	return true
+}
+
+// walk calls fn for err and its wrapped errors in depth-first order as errors.As does, until fn returns true. It returns true if fn returned true.
+func (w *walker) walk(err error, fn func(err error) bool) bool {
+	for err != nil {
+		if !w.enter(err) {
+			return false
+		}
+		if fn(err) {
+			return true
+		}
+
+		switch e := err.(type) {
+		case interface{ Unwrap() error }:
+			err = e.Unwrap()
+		case interface{ Unwrap() []error }:
+			for _, child := range e.Unwrap() {
+				if w.walk(child, fn) {
+					return true
+				}
+			}
+			return false
+		default:
+			return false
+		}
+	}
+
+	return false
+}
+
+// find returns the first *Error in err and its wrapped errors. An error that sets *Error by its As method is also matched as errors.As does.
+func (w *walker) find(err error) *Error {
+	var found *Error
+	w.walk(err, func(err error) bool {
+		if e, ok := err.(*Error); ok {
+			found = e
+			return true
+		}
+		return callAs(err, &found)
+	})
+	return found
+}
+
+// findErrors returns the first *Errors in err and its wrapped errors. An error that sets *Errors by its As method is also matched as errors.As does.
+func (w *walker) findErrors(err error) *Errors {
+	var found *Errors
+	w.walk(err, func(err error) bool {
+		if e, ok := err.(*Errors); ok {
+			found = e
+			return true
+		}
+		return callAs(err, &found)
+	})
+	return found
+}
+
+// callAs calls As method of err with target. As of *Errors is not called because it traverses its errors by errors.As without the limit of the walker; the walker visits them by Unwrap() []error in the same order instead.
+func callAs(err error, target any) bool {
+	if _, ok := err.(*Errors); ok {
+		return false
+	}
+	if a, ok := err.(interface{ As(any) bool }); ok {
+		return a.As(target)
+	}
+	return false
+}
+
+// chain returns x and all wrapped *Error from outermost to innermost.
+func (x *Error) chain() []*Error {
+	w := newWalker()
+	if !w.enter(x) {
+		return nil
+	}
+
+	errs := []*Error{x}
+	for cur := x; cur.cause != nil; {
+		next := w.find(cur.cause)
+		if next == nil {
+			break
+		}
+		errs = append(errs, next)
+		cur = next
+	}
+
+	return errs
+}
+
+// outerLevels returns *Error in err that are found before *Errors from outermost to innermost, and the outermost *Errors in err. It is used to combine attributes of *Error wrapping *Errors with attributes of the *Errors.
+func outerLevels(err error) ([]*Error, *Errors) {
+	var levels []*Error
+	var errs *Errors
+	newWalker().walk(err, func(err error) bool {
+		switch e := err.(type) {
+		case *Errors:
+			errs = e
+			return true
+		case *Error:
+			levels = append(levels, e)
+		}
+		return false
+	})
+	return levels, errs
+}
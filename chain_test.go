@@ -0,0 +1,262 @@
+package goerr_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/m-mizutani/goerr/v2"
+)
+
+// cyclicError is a third-party error whose Unwrap returns an error that eventually wraps itself
+type cyclicError struct {
+	next error
+}
+
+func (x *cyclicError) Error() string { return "cyclic" }
+func (x *cyclicError) Unwrap() error { return x.next }
+
+// valueCyclicError is not a pointer, so it can not be tracked as visited
+type valueCyclicError struct{}
+
+func (x valueCyclicError) Error() string { return "value cyclic" }
+func (x valueCyclicError) Unwrap() error { return x }
+
+// multiCyclicError has Unwrap() []error that includes itself
+type multiCyclicError struct {
+	errs []error
+}
+
+func (x *multiCyclicError) Error() string   { return "multi cyclic" }
+func (x *multiCyclicError) Unwrap() []error { return x.errs }
+
+func TestChainCycle(t *testing.T) {
+	tagA := goerr.NewTag("a")
+	key := goerr.NewTypedKey[string]("key")
+
+	cyclic := &cyclicError{}
+	inner := goerr.Wrap(cyclic, "inner", goerr.V("inner", 1), goerr.T(tagA), goerr.TV(key, "inner"))
+	cyclic.next = inner
+	outer := goerr.Wrap(inner, "outer", goerr.V("outer", 2))
+
+	if v := outer.Values(); v["inner"] != 1 || v["outer"] != 2 {
+		t.Errorf("Unexpected values: %v", v)
+	}
+	if !outer.HasTag(tagA) {
+		t.Error("Expected tag a")
+	}
+	if !goerr.HasTag(outer, tagA) || !goerr.HasTag(cyclic, tagA) {
+		t.Error("Expected tag a by goerr.HasTag")
+	}
+	if goerr.AsErrors(cyclic) != nil {
+		t.Error("Expected no Errors")
+	}
+	if v, ok := goerr.GetTypedValue(outer, key); !ok || v != "inner" {
+		t.Errorf("Unexpected typed value: %v", v)
+	}
+	if tv := outer.TypedValues(); tv["key"] != "inner" {
+		t.Errorf("Unexpected typed values: %v", tv)
+	}
+
+	p := outer.Printable()
+	innerP, ok := p.Cause.(*goerr.Printable)
+	if !ok || innerP.Message != "inner" {
+		t.Fatalf("Unexpected cause: %#v", p.Cause)
+	}
+	cause, ok := innerP.Cause.(*goerr.PrintableCause)
+	if !ok || cause.Type != "*goerr_test.cyclicError" {
+		t.Errorf("Unexpected cause of inner: %#v", innerP.Cause)
+	}
+	if _, err := json.Marshal(outer); err != nil {
+		t.Errorf("Failed to marshal: %v", err)
+	}
+}
+
+func TestChainCycleWithoutGoErr(t *testing.T) {
+	cyclic := &cyclicError{}
+	cyclic.next = &cyclicError{next: cyclic}
+
+	if e := goerr.Unwrap(cyclic); e != nil {
+		t.Errorf("Expected nil, got %v", e)
+	}
+
+	err := goerr.Wrap(cyclic, "wrapped", goerr.V("k", "v"))
+	if v := err.Values(); len(v) != 1 || v["k"] != "v" {
+		t.Errorf("Unexpected values: %v", v)
+	}
+
+	// Non-pointer cycle is cut off by depth limit
+	err = goerr.Wrap(valueCyclicError{}, "wrapped")
+	if len(err.Values()) != 0 || len(err.Tags()) != 0 {
+		t.Error("Expected no values and tags")
+	}
+	if goerr.Unwrap(valueCyclicError{}) != nil {
+		t.Error("Expected nil")
+	}
+
+	multi := &multiCyclicError{}
+	target := goerr.New("target", goerr.V("found", true))
+	multi.errs = []error{multi, target}
+	if e := goerr.Unwrap(multi); e != target {
+		t.Errorf("Expected target, got %v", e)
+	}
+
+	tagB := goerr.NewTag("b")
+	if goerr.HasTag(cyclic, tagB) || goerr.HasTag(valueCyclicError{}, tagB) || goerr.HasTag(multi, tagB) {
+		t.Error("Expected no tag")
+	}
+	errs := goerr.Join(goerr.New("in errors", goerr.T(tagB)))
+	multi.errs = append(multi.errs, errs)
+	if goerr.AsErrors(multi) != errs || !goerr.HasTag(multi, tagB) {
+		t.Error("Expected Errors found through cyclic multi error")
+	}
+}
+
+func TestChainDepthLimit(t *testing.T) {
+	defer goerr.SetMaxChainDepth(0)
+
+	var err error = goerr.New("root", goerr.V("root", true))
+	for i := 0; i < 3000; i++ {
+		err = goerr.Wrap(err, "wrap")
+	}
+
+	goErr := goerr.Unwrap(err)
+	if v := goErr.Values(); len(v) != 0 {
+		t.Errorf("Values deeper than default limit should be ignored: %v", v)
+	}
+
+	goerr.SetMaxChainDepth(5000)
+	if goerr.MaxChainDepth() != 5000 {
+		t.Errorf("Unexpected max depth: %d", goerr.MaxChainDepth())
+	}
+	if v := goErr.Values(); v["root"] != true {
+		t.Errorf("Expected root value: %v", v)
+	}
+
+	goerr.SetMaxChainDepth(3)
+	depth := 0
+	for p := goErr.Printable(); p != nil; depth++ {
+		next, ok := p.Cause.(*goerr.Printable)
+		if !ok {
+			break
+		}
+		p = next
+	}
+	if depth != 2 {
+		t.Errorf("Expected 3 levels in Printable, got %d", depth+1)
+	}
+
+	goerr.SetMaxChainDepth(-1)
+	if goerr.MaxChainDepth() != goerr.DefaultMaxChainDepth {
+		t.Errorf("Expected default max depth, got %d", goerr.MaxChainDepth())
+	}
+}
+
+func TestLogValueDepthLimit(t *testing.T) {
+	defer goerr.SetMaxChainDepth(0)
+
+	var err error = goerr.New("root", goerr.Severity(goerr.SeverityCritical), goerr.Op("root"))
+	for i := 0; i < 20000; i++ {
+		var options []goerr.Option
+		if i == 0 || i == 19998 {
+			options = append(options, goerr.Op("wrap"))
+		}
+		err = goerr.Wrap(err, "wrap", options...)
+	}
+	goErr := goerr.Unwrap(err)
+
+	// Levels are nested up to MaxChainDepth, and deeper error is logged only by message and type
+	goerr.SetMaxChainDepth(3)
+	depth := 0
+	v := goErr.LogValue()
+	for {
+		attrs := map[string]slog.Value{}
+		for _, attr := range v.Group() {
+			attrs[attr.Key] = attr.Value
+		}
+		if _, ok := attrs["stacktrace"]; !ok {
+			break
+		}
+		cause, ok := attrs["cause"]
+		if !ok {
+			t.Fatalf("Expected cause at depth %d", depth)
+		}
+		// Only the second level has operation within the limit
+		if _, ok := attrs["ops"]; ok != (depth <= 1) {
+			t.Errorf("Unexpected ops at depth %d: %v", depth, attrs["ops"])
+		}
+		v = cause
+		depth++
+	}
+	if depth != 3 {
+		t.Errorf("Expected 3 levels in LogValue, got %d", depth)
+	}
+
+	// Whole chain within the limit is logged in linear time
+	goerr.SetMaxChainDepth(30000)
+	var buf bytes.Buffer
+	slog.New(slog.NewJSONHandler(&buf, nil)).Error("deep", slog.Any("error", goErr))
+	if !strings.Contains(buf.String(), `"severity":"critical"`) || !strings.Contains(buf.String(), `"message":"root"`) {
+		t.Errorf("Expected root level in output")
+	}
+}
+
+// asError is a third-party error that converts itself to goerr errors by As method
+type asError struct {
+	goErr *goerr.Error
+	errs  *goerr.Errors
+}
+
+func (x *asError) Error() string { return "as error" }
+func (x *asError) As(target any) bool {
+	switch t := target.(type) {
+	case **goerr.Error:
+		if x.goErr != nil {
+			*t = x.goErr
+			return true
+		}
+	case **goerr.Errors:
+		if x.errs != nil {
+			*t = x.errs
+			return true
+		}
+	}
+	return false
+}
+
+func TestUnwrapByAsMethod(t *testing.T) {
+	goErr := goerr.New("converted", goerr.V("k", "v"))
+	errs := goerr.Join(goerr.New("a"))
+	err := fmt.Errorf("wrapped: %w", &asError{goErr: goErr, errs: errs})
+
+	var target *goerr.Error
+	if !errors.As(err, &target) || target != goErr {
+		t.Fatal("Expected errors.As to find error by As method")
+	}
+	if got := goerr.Unwrap(err); got != goErr {
+		t.Errorf("Expected Unwrap to find error by As method, got %v", got)
+	}
+	if got := goerr.Values(err); got["k"] != "v" {
+		t.Errorf("Expected values of error found by As method, got %v", got)
+	}
+	if got := goerr.AsErrors(err); got != errs {
+		t.Errorf("Expected AsErrors to find errors by As method, got %v", got)
+	}
+
+	// As method returning false is skipped
+	if goerr.Unwrap(&asError{}) != nil || goerr.AsErrors(&asError{}) != nil {
+		t.Error("Expected nil for As method returning false")
+	}
+}
+
+func TestUnwrapThroughJoin(t *testing.T) {
+	target := goerr.New("target")
+	joined := errors.Join(errors.New("plain"), target)
+	if goerr.Unwrap(joined) != target {
+		t.Error("Unwrap should find *goerr.Error in joined errors")
+	}
+}
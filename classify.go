@@ -0,0 +1,110 @@
+package goerr
+
+import (
+	"context"
+	"reflect"
+	"sync/atomic"
+	"time"
+)
+
+// Predefined tags attached by automatic classification. See SetAutoClassification.
+var (
+	// TagCanceled is attached to errors caused by context.Canceled.
+	TagCanceled = NewTag("canceled")
+	// TagTimeout is attached to errors caused by context.DeadlineExceeded or an error whose Timeout() returns true, such as net.Error.
+	TagTimeout = NewTag("timeout")
+	// TagTemporary is attached to errors caused by an error whose Temporary() returns true.
+	TagTemporary = NewTag("temporary")
+)
+
+// DeadlineRemainingKey is the value key of remaining time until the deadline of the context given to WrapCtx. It is negative if the deadline has passed.
+const DeadlineRemainingKey = "deadline_remaining"
+
+var autoClassification atomic.Bool
+
+// SetAutoClassification enables or disables automatic classification of causes. If enabled, Wrap, WrapCtx, With and other functions wrapping a cause attach TagCanceled, TagTimeout and TagTemporary according to the cause. WrapCtx also sets DeadlineRemainingKey if the cause is classified as canceled or timeout and the context has a deadline. It is disabled by default.
+//
+// Usage:
+//
+//	func main() {
+//		goerr.SetAutoClassification(true)
+//		...
+//	}
+//
+//	if goerr.HasTag(err, goerr.TagTimeout) {
+//		w.WriteHeader(http.StatusGatewayTimeout)
+//	}
+func SetAutoClassification(enabled bool) {
+	autoClassification.Store(enabled)
+}
+
+// AutoClassification returns true if automatic classification is enabled.
+func AutoClassification() bool {
+	return autoClassification.Load()
+}
+
+// setCause sets cause of x and applies processing of the cause: stack trace adoption, enrichment and automatic classification. ctx can be nil if no context is supplied.
+func (x *Error) setCause(ctx context.Context, cause error) {
+	x.cause = cause
+	x.adoptStack()
+	x.enrich()
+
+	if AutoClassification() {
+		x.classify(ctx)
+	}
+}
+
+// classify attaches predefined tags according to the cause. The cause is traversed by the chain walker instead of errors.Is and errors.As so that a cyclic cause does not hang. As errors.As does, Timeout() and Temporary() are checked on the first error implementing them.
+func (x *Error) classify(ctx context.Context) {
+	if x.cause == nil {
+		return
+	}
+
+	var canceled, deadlineExceeded bool
+	var timeout, temporary *bool
+	newWalker().walk(x.cause, func(err error) bool {
+		canceled = canceled || isError(err, context.Canceled)
+		deadlineExceeded = deadlineExceeded || isError(err, context.DeadlineExceeded)
+		if t, ok := err.(interface{ Timeout() bool }); ok && timeout == nil {
+			v := t.Timeout()
+			timeout = &v
+		}
+		if t, ok := err.(interface{ Temporary() bool }); ok && temporary == nil {
+			v := t.Temporary()
+			temporary = &v
+		}
+		return false
+	})
+
+	classified := false
+	if canceled {
+		x.tags[TagCanceled] = struct{}{}
+		classified = true
+	}
+	if deadlineExceeded || (timeout != nil && *timeout) {
+		x.tags[TagTimeout] = struct{}{}
+		classified = true
+	}
+	if temporary != nil && *temporary {
+		x.tags[TagTemporary] = struct{}{}
+	}
+
+	if classified && ctx != nil {
+		if deadline, ok := ctx.Deadline(); ok {
+			if _, exists := x.values[DeadlineRemainingKey]; !exists {
+				x.values[DeadlineRemainingKey] = time.Until(deadline)
+			}
+		}
+	}
+}
+
+// isError reports whether err matches target without unwrapping, as a single step of errors.Is.
+func isError(err, target error) bool {
+	if reflect.TypeOf(err).Comparable() && err == target {
+		return true
+	}
+	if is, ok := err.(interface{ Is(error) bool }); ok {
+		return is.Is(target)
+	}
+	return false
+}
@@ -0,0 +1,133 @@
+package goerr_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/m-mizutani/goerr/v2"
+)
+
+func enableAutoClassification(t *testing.T) {
+	goerr.SetAutoClassification(true)
+	t.Cleanup(func() { goerr.SetAutoClassification(false) })
+}
+
+func TestAutoClassification(t *testing.T) {
+	enableAutoClassification(t)
+
+	dnsTimeout := &net.DNSError{Err: "i/o timeout", Name: "example.com", IsTimeout: true}
+	dnsTemporary := &net.DNSError{Err: "server misbehaving", Name: "example.com", IsTemporary: true}
+
+	testCases := map[string]struct {
+		err  *goerr.Error
+		tags []string
+	}{
+		"canceled": {
+			err:  goerr.Wrap(context.Canceled, "failed"),
+			tags: []string{"canceled"},
+		},
+		"deadline exceeded": {
+			// context.DeadlineExceeded is also temporary
+			err:  goerr.Wrap(fmt.Errorf("query: %w", context.DeadlineExceeded), "failed"),
+			tags: []string{"temporary", "timeout"},
+		},
+		"net timeout": {
+			err:  goerr.Wrap(&net.OpError{Op: "dial", Net: "tcp", Err: dnsTimeout}, "failed"),
+			tags: []string{"temporary", "timeout"},
+		},
+		"net temporary": {
+			err:  goerr.With(dnsTemporary),
+			tags: []string{"temporary"},
+		},
+		"Error.Wrap": {
+			err:  goerr.New("base").Wrap(context.Canceled),
+			tags: []string{"canceled"},
+		},
+		"other error": {
+			err:  goerr.Wrap(errors.New("failed"), "failed"),
+			tags: nil,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			tags := tc.err.Tags()
+			sort.Strings(tags)
+			if len(tags) != len(tc.tags) {
+				t.Fatalf("Expected tags %v, got %v", tc.tags, tags)
+			}
+			for i := range tags {
+				if tags[i] != tc.tags[i] {
+					t.Errorf("Expected tags %v, got %v", tc.tags, tags)
+				}
+			}
+		})
+	}
+
+	// Tags are merged to outer errors
+	if !goerr.HasTag(goerr.Wrap(goerr.Wrap(context.Canceled, "inner"), "outer"), goerr.TagCanceled) {
+		t.Error("Expected canceled tag through wrapped errors")
+	}
+}
+
+func TestAutoClassificationDisabled(t *testing.T) {
+	if goerr.AutoClassification() {
+		t.Fatal("Auto classification should be disabled by default")
+	}
+	if goerr.HasTag(goerr.Wrap(context.Canceled, "failed"), goerr.TagCanceled) {
+		t.Error("Tag should not be attached when disabled")
+	}
+}
+
+func TestAutoClassificationDeadline(t *testing.T) {
+	enableAutoClassification(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
+	defer cancel()
+
+	err := goerr.WrapCtx(ctx, context.DeadlineExceeded, "failed")
+	remaining, ok := err.Values()[goerr.DeadlineRemainingKey].(time.Duration)
+	if !ok || remaining <= 0 || remaining > time.Hour {
+		t.Errorf("Unexpected remaining deadline: %v", err.Values())
+	}
+	if !err.HasTag(goerr.TagTimeout) {
+		t.Error("Expected timeout tag")
+	}
+
+	// Deadline is not recorded for errors not classified as canceled or timeout
+	err = goerr.WrapCtx(ctx, errors.New("failed"), "failed")
+	if _, ok := err.Values()[goerr.DeadlineRemainingKey]; ok {
+		t.Error("Deadline should not be recorded for other errors")
+	}
+
+	// Explicit value is not overwritten
+	err = goerr.WrapCtx(ctx, context.Canceled, "failed", goerr.V(goerr.DeadlineRemainingKey, "custom"))
+	if err.Values()[goerr.DeadlineRemainingKey] != "custom" {
+		t.Errorf("Explicit value should not be overwritten: %v", err.Values())
+	}
+}
+
+func TestAutoClassificationCycle(t *testing.T) {
+	enableAutoClassification(t)
+
+	cyclic := &cyclicError{}
+	cyclic.next = &cyclicError{next: cyclic}
+	if tags := goerr.Wrap(cyclic, "failed").Tags(); len(tags) != 0 {
+		t.Errorf("Expected no tags, got %v", tags)
+	}
+
+	multi := &multiCyclicError{}
+	multi.errs = []error{multi, fmt.Errorf("query: %w", context.Canceled)}
+	if err := goerr.Wrap(multi, "failed"); !goerr.HasTag(err, goerr.TagCanceled) {
+		t.Errorf("Expected canceled tag, got %v", err.Tags())
+	}
+
+	if err := goerr.Wrap(valueCyclicError{}, "failed"); len(err.Tags()) != 0 {
+		t.Errorf("Expected no tags, got %v", err.Tags())
+	}
+}
@@ -0,0 +1,233 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/m-mizutani/goerr/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// catalog is the definition file of errors. It can be written in YAML or JSON.
+type catalog struct {
+	Package string     `json:"package" yaml:"package"`
+	Imports []string   `json:"imports" yaml:"imports"`
+	Tags    []tagDef   `json:"tags" yaml:"tags"`
+	Keys    []keyDef   `json:"keys" yaml:"keys"`
+	Errors  []errorDef `json:"errors" yaml:"errors"`
+}
+
+type tagDef struct {
+	Name        string `json:"name" yaml:"name"`
+	Ident       string `json:"ident" yaml:"ident"`
+	Description string `json:"description" yaml:"description"`
+}
+
+type keyDef struct {
+	Name        string `json:"name" yaml:"name"`
+	Type        string `json:"type" yaml:"type"`
+	Ident       string `json:"ident" yaml:"ident"`
+	Description string `json:"description" yaml:"description"`
+}
+
+type errorDef struct {
+	ID          string   `json:"id" yaml:"id"`
+	Name        string   `json:"name" yaml:"name"`
+	Message     string   `json:"message" yaml:"message"`
+	Description string   `json:"description" yaml:"description"`
+	Tags        []string `json:"tags" yaml:"tags"`
+	Values      []string `json:"values" yaml:"values"`
+}
+
+// loadCatalog reads a catalog file. A file with .json extension is parsed as JSON, otherwise as YAML.
+func loadCatalog(path string) (*catalog, error) {
+	raw, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, goerr.Wrap(err, "failed to read catalog file", goerr.V("path", path))
+	}
+
+	var c catalog
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		decoder := json.NewDecoder(bytes.NewReader(raw))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&c); err != nil {
+			return nil, goerr.Wrap(err, "failed to parse JSON catalog", goerr.V("path", path))
+		}
+	} else {
+		decoder := yaml.NewDecoder(bytes.NewReader(raw))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(&c); err != nil {
+			return nil, goerr.Wrap(err, "failed to parse YAML catalog", goerr.V("path", path))
+		}
+	}
+
+	if err := c.normalize(); err != nil {
+		return nil, goerr.Wrap(err, "invalid catalog", goerr.V("path", path))
+	}
+
+	return &c, nil
+}
+
+// normalize fills default identifiers and validates the catalog.
+func (x *catalog) normalize() error {
+	idents := map[string]string{}
+	declare := func(ident, owner string) error {
+		if !token.IsIdentifier(ident) {
+			return goerr.New("invalid Go identifier", goerr.V("ident", ident), goerr.V("owner", owner))
+		}
+		if prev, ok := idents[ident]; ok {
+			return goerr.New("duplicate Go identifier", goerr.V("ident", ident), goerr.V("owner", owner), goerr.V("previous", prev))
+		}
+		idents[ident] = owner
+		return nil
+	}
+
+	tagNames := map[string]bool{}
+	for i := range x.Tags {
+		t := &x.Tags[i]
+		if t.Name == "" {
+			return goerr.New("tag name is required", goerr.V("index", i))
+		}
+		if tagNames[t.Name] {
+			return goerr.New("duplicate tag name", goerr.V("tag", t.Name))
+		}
+		tagNames[t.Name] = true
+
+		if t.Ident == "" {
+			t.Ident = "Tag" + camelCase(t.Name)
+		}
+		if err := declare(t.Ident, "tag "+t.Name); err != nil {
+			return err
+		}
+	}
+
+	keyNames := map[string]bool{}
+	for i := range x.Keys {
+		k := &x.Keys[i]
+		if k.Name == "" || k.Type == "" {
+			return goerr.New("key name and type are required", goerr.V("index", i))
+		}
+		if keyNames[k.Name] {
+			return goerr.New("duplicate key name", goerr.V("key", k.Name))
+		}
+		keyNames[k.Name] = true
+
+		if k.Ident == "" {
+			k.Ident = camelCase(k.Name) + "Key"
+		}
+		if err := declare(k.Ident, "key "+k.Name); err != nil {
+			return err
+		}
+	}
+
+	ids := map[string]bool{}
+	for i := range x.Errors {
+		e := &x.Errors[i]
+		if e.ID == "" || e.Message == "" {
+			return goerr.New("error id and message are required", goerr.V("index", i))
+		}
+		if ids[e.ID] {
+			return goerr.New("duplicate error ID", goerr.V("id", e.ID))
+		}
+		ids[e.ID] = true
+
+		if e.Name == "" {
+			e.Name = camelCase(e.ID)
+		}
+		for _, prefix := range []string{"Err", "New", "Wrap"} {
+			if err := declare(prefix+e.Name, "error "+e.ID); err != nil {
+				return err
+			}
+		}
+
+		for _, t := range e.Tags {
+			if !tagNames[t] {
+				return goerr.New("unknown tag", goerr.V("id", e.ID), goerr.V("tag", t))
+			}
+		}
+		for _, v := range e.Values {
+			if !keyNames[v] {
+				return goerr.New("unknown key", goerr.V("id", e.ID), goerr.V("key", v))
+			}
+		}
+	}
+
+	return nil
+}
+
+func (x *catalog) tag(name string) *tagDef {
+	for i := range x.Tags {
+		if x.Tags[i].Name == name {
+			return &x.Tags[i]
+		}
+	}
+	return nil
+}
+
+func (x *catalog) key(name string) *keyDef {
+	for i := range x.Keys {
+		if x.Keys[i].Name == name {
+			return &x.Keys[i]
+		}
+	}
+	return nil
+}
+
+// commonInitialisms is a subset of initialisms used by golint.
+var commonInitialisms = map[string]bool{
+	"API": true, "DB": true, "HTTP": true, "ID": true, "IP": true, "JSON": true,
+	"SQL": true, "TCP": true, "TLS": true, "UID": true, "URL": true, "UUID": true,
+}
+
+// camelCase converts "user_not_found", "user-not-found" or "user.not_found" into "UserNotFound".
+func camelCase(s string) string {
+	words := strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	var b strings.Builder
+	for _, w := range words {
+		if upper := strings.ToUpper(w); commonInitialisms[upper] {
+			b.WriteString(upper)
+			continue
+		}
+		runes := []rune(w)
+		runes[0] = unicode.ToUpper(runes[0])
+		b.WriteString(string(runes))
+	}
+
+	result := b.String()
+	if result != "" && unicode.IsDigit([]rune(result)[0]) {
+		result = "X" + result
+	}
+	return result
+}
+
+// paramName converts a key name into a parameter name such as "userID".
+func paramName(s string) string {
+	name := camelCase(s)
+	if name == "" {
+		return "v"
+	}
+
+	// Lower the leading word, including whole initialism such as "ID" or "URL"
+	runes := []rune(name)
+	n := 1
+	for n < len(runes) && unicode.IsUpper(runes[n]) && (n+1 >= len(runes) || unicode.IsUpper(runes[n+1])) {
+		n++
+	}
+	for i := 0; i < n; i++ {
+		runes[i] = unicode.ToLower(runes[i])
+	}
+	name = string(runes)
+
+	if token.IsKeyword(name) || name == "cause" || name == "options" || name == "goerr" {
+		name += "Value"
+	}
+	return name
+}
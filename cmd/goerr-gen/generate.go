@@ -0,0 +1,182 @@
+package main
+
+import (
+	"bytes"
+	"go/format"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/m-mizutani/goerr/v2"
+)
+
+var codeTemplate = template.Must(template.New("code").Funcs(template.FuncMap{
+	"quote": strconv.Quote,
+	"comment": func(s string) string {
+		return strings.ReplaceAll(strings.TrimSpace(s), "\n", "\n// ")
+	},
+}).Parse(`// Code generated by goerr-gen. DO NOT EDIT.
+
+package {{ .Package }}
+
+import (
+{{- range .Imports }}
+	{{ quote . }}
+{{- end }}
+
+	"github.com/m-mizutani/goerr/v2"
+)
+{{ if .Tags }}
+var (
+{{- range .Tags }}
+	// {{ .Ident }} is error tag {{ quote .Name }}.{{ if .Description }} {{ comment .Description }}{{ end }}
+	{{ .Ident }} = goerr.NewTag({{ quote .Name }})
+{{- end }}
+)
+{{ end }}
+{{- if .Keys }}
+var (
+{{- range .Keys }}
+	// {{ .Ident }} is typed key {{ quote .Name }}.{{ if .Description }} {{ comment .Description }}{{ end }}
+	{{ .Ident }} = goerr.NewTypedKey[{{ .Type }}]({{ quote .Name }})
+{{- end }}
+)
+{{ end }}
+{{- range .Errors }}
+// Err{{ .Name }} is sentinel error of {{ quote .ID }}. Errors created by New{{ .Name }} and Wrap{{ .Name }} match it with errors.Is.{{ if .Description }}
+// {{ comment .Description }}{{ end }}
+var Err{{ .Name }} = goerr.Sentinel({{ quote .ID }}, {{ quote .Message }}{{ range .Tags }}, goerr.T({{ .Ident }}){{ end }})
+
+// New{{ .Name }} creates a new error of {{ quote .ID }}.
+func New{{ .Name }}({{ range .Params }}{{ .Name }} {{ .Type }}, {{ end }}options ...goerr.Option) *goerr.Error {
+	return goerr.New({{ quote .Message }}, {{ template "options" . }})
+}
+
+// Wrap{{ .Name }} creates a new error of {{ quote .ID }} with cause.
+func Wrap{{ .Name }}(cause error, {{ range .Params }}{{ .Name }} {{ .Type }}, {{ end }}options ...goerr.Option) *goerr.Error {
+	return goerr.Wrap(cause, {{ quote .Message }}, {{ template "options" . }})
+}
+{{ end }}
+{{- define "options" -}}
+append([]goerr.Option{
+		goerr.ID({{ quote .ID }}),
+	{{- range .Tags }}
+		goerr.T({{ .Ident }}),
+	{{- end }}
+	{{- range .Params }}
+		goerr.TV({{ .Key }}, {{ .Name }}),
+	{{- end }}
+		goerr.Unstack(1),
+	}, options...)...
+{{- end -}}
+`))
+
+type codeParam struct {
+	Name string
+	Type string
+	Key  string
+}
+
+type codeError struct {
+	errorDef
+	Tags   []*tagDef
+	Params []codeParam
+}
+
+type codeData struct {
+	Package string
+	Imports []string
+	Tags    []tagDef
+	Keys    []keyDef
+	Errors  []codeError
+}
+
+// generateCode renders Go source code of tags, typed keys, sentinel errors and constructors.
+func generateCode(c *catalog) ([]byte, error) {
+	data := codeData{
+		Package: c.Package,
+		Imports: c.Imports,
+		Tags:    c.Tags,
+		Keys:    c.Keys,
+	}
+
+	for _, e := range c.Errors {
+		ce := codeError{errorDef: e}
+		for _, t := range e.Tags {
+			ce.Tags = append(ce.Tags, c.tag(t))
+		}
+		for _, v := range e.Values {
+			k := c.key(v)
+			ce.Params = append(ce.Params, codeParam{
+				Name: paramName(k.Name),
+				Type: k.Type,
+				Key:  k.Ident,
+			})
+		}
+		data.Errors = append(data.Errors, ce)
+	}
+
+	var buf bytes.Buffer
+	if err := codeTemplate.Execute(&buf, data); err != nil {
+		return nil, goerr.Wrap(err, "failed to render code")
+	}
+
+	code, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, goerr.Wrap(err, "failed to format generated code", goerr.V("code", buf.String()))
+	}
+
+	return code, nil
+}
+
+// generateMarkdown renders reference document of all errors.
+func generateMarkdown(c *catalog) []byte {
+	var b strings.Builder
+
+	b.WriteString("<!-- Code generated by goerr-gen. DO NOT EDIT. -->\n\n")
+	b.WriteString("# Error Reference\n\n")
+	b.WriteString("| ID | Message | Tags | Required values |\n")
+	b.WriteString("|----|---------|------|-----------------|\n")
+	for _, e := range c.Errors {
+		var tagList, valueList []string
+		for _, t := range e.Tags {
+			tagList = append(tagList, "`"+t+"`")
+		}
+		for _, v := range e.Values {
+			valueList = append(valueList, "`"+v+"` ("+"`"+c.key(v).Type+"`)")
+		}
+		b.WriteString("| `" + e.ID + "` | " + escapeCell(e.Message) + " | " +
+			strings.Join(tagList, ", ") + " | " + strings.Join(valueList, ", ") + " |\n")
+	}
+
+	for _, e := range c.Errors {
+		b.WriteString("\n## " + e.ID + "\n\n")
+		if e.Description != "" {
+			b.WriteString(strings.TrimSpace(e.Description) + "\n\n")
+		}
+		b.WriteString("- Message: " + e.Message + "\n")
+		b.WriteString("- Constructors: `New" + e.Name + "`, `Wrap" + e.Name + "`\n")
+		b.WriteString("- Sentinel: `Err" + e.Name + "`\n")
+		for _, t := range e.Tags {
+			line := "- Tag: `" + t + "`"
+			if desc := c.tag(t).Description; desc != "" {
+				line += " — " + desc
+			}
+			b.WriteString(line + "\n")
+		}
+		for _, v := range e.Values {
+			k := c.key(v)
+			line := "- Required value: `" + k.Name + "` (`" + k.Type + "`)"
+			if k.Description != "" {
+				line += " — " + k.Description
+			}
+			b.WriteString(line + "\n")
+		}
+	}
+
+	return []byte(b.String())
+}
+
+func escapeCell(s string) string {
+	return strings.ReplaceAll(s, "|", "\\|")
+}
@@ -0,0 +1,8 @@
+module github.com/m-mizutani/goerr/v2/cmd/goerr-gen
+
+go 1.21
+
+require (
+	github.com/m-mizutani/goerr/v2 v2.0.0
+	gopkg.in/yaml.v3 v3.0.1
+)
@@ -0,0 +1,77 @@
+// Command goerr-gen generates Go code of tags, typed keys, sentinel errors and constructors from a YAML or JSON error catalog. It also generates a Markdown reference of all errors.
+//
+// Usage:
+//
+//	//go:generate go run github.com/m-mizutani/goerr/v2/cmd/goerr-gen@latest -input errors.yaml -output errors_gen.go -doc ERRORS.md
+//
+// Catalog format:
+//
+//	package: apperr
+//	imports: ["time"]
+//	tags:
+//	  - name: not_found
+//	    description: Resource does not exist
+//	keys:
+//	  - name: user_id
+//	    type: string
+//	  - name: timeout
+//	    type: time.Duration
+//	errors:
+//	  - id: user_not_found
+//	    message: user not found
+//	    tags: [not_found]
+//	    values: [user_id]
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/m-mizutani/goerr/v2"
+)
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "goerr-gen: %+v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	flags := flag.NewFlagSet("goerr-gen", flag.ContinueOnError)
+	input := flags.String("input", "errors.yaml", "catalog file (YAML, or JSON with .json extension)")
+	output := flags.String("output", "errors_gen.go", "output Go file")
+	doc := flags.String("doc", "", "output Markdown reference file (optional)")
+	pkg := flags.String("package", "", "package name of generated code (overrides catalog)")
+	if err := flags.Parse(args); err != nil {
+		return goerr.Wrap(err, "failed to parse flags")
+	}
+
+	c, err := loadCatalog(*input)
+	if err != nil {
+		return err
+	}
+	if *pkg != "" {
+		c.Package = *pkg
+	}
+	if c.Package == "" {
+		return goerr.New("package name is required in catalog or -package flag")
+	}
+
+	code, err := generateCode(c)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(*output, code, 0644); err != nil {
+		return goerr.Wrap(err, "failed to write code", goerr.V("path", *output))
+	}
+
+	if *doc != "" {
+		if err := os.WriteFile(*doc, generateMarkdown(c), 0644); err != nil {
+			return goerr.Wrap(err, "failed to write document", goerr.V("path", *doc))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"errors"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/m-mizutani/goerr/v2"
+)
+
+func TestCamelCase(t *testing.T) {
+	testCases := map[string]string{
+		"user_not_found": "UserNotFound",
+		"user-id":        "UserID",
+		"db.timeout":     "DBTimeout",
+		"http_url":       "HTTPURL",
+		"404_not_found":  "X404NotFound",
+	}
+	for input, expected := range testCases {
+		if actual := camelCase(input); actual != expected {
+			t.Errorf("camelCase(%q): expected %q, got %q", input, expected, actual)
+		}
+	}
+}
+
+func TestParamName(t *testing.T) {
+	testCases := map[string]string{
+		"user_id":  "userID",
+		"id_token": "idToken",
+		"url":      "url",
+		"type":     "typeValue",
+		"cause":    "causeValue",
+	}
+	for input, expected := range testCases {
+		if actual := paramName(input); actual != expected {
+			t.Errorf("paramName(%q): expected %q, got %q", input, expected, actual)
+		}
+	}
+}
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	output := filepath.Join(dir, "errors_gen.go")
+	doc := filepath.Join(dir, "ERRORS.md")
+
+	if err := run([]string{"-input", "testdata/catalog.json", "-output", output, "-doc", doc}); err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	code, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := parser.ParseFile(token.NewFileSet(), output, code, parser.AllErrors); err != nil {
+		t.Fatalf("generated code is invalid: %v\n%s", err, code)
+	}
+	for _, expected := range []string{
+		"package apperr",
+		`TagNotFound = goerr.NewTag("not_found")`,
+		`UserIDKey = goerr.NewTypedKey[string]("user_id")`,
+		`KindKey = goerr.NewTypedKey[int]("type")`,
+		`var ErrUserNotFound = goerr.Sentinel("user.not_found", "user | not found", goerr.T(TagNotFound))`,
+		"func NewUserNotFound(userID string, typeValue int, options ...goerr.Option) *goerr.Error {",
+		"func WrapUserNotFound(cause error, userID string, typeValue int, options ...goerr.Option) *goerr.Error {",
+		"goerr.TV(KindKey, typeValue),",
+	} {
+		if !strings.Contains(string(code), expected) {
+			t.Errorf("generated code does not contain %q:\n%s", expected, code)
+		}
+	}
+
+	md, err := os.ReadFile(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(md), "| `user.not_found` | user \\| not found | `not_found` | `user_id` (`string`), `type` (`int`) |") {
+		t.Errorf("unexpected document:\n%s", md)
+	}
+}
+
+func TestLoadCatalogYAML(t *testing.T) {
+	c := writeCatalog(t, `
+package: apperr
+keys:
+  - name: count
+    type: int
+errors:
+  - id: too_many
+    message: too many items
+    values: [count]
+`)
+	if c.Errors[0].Name != "TooMany" || c.Keys[0].Ident != "CountKey" {
+		t.Errorf("unexpected catalog: %+v", c)
+	}
+}
+
+func TestLoadCatalogInvalid(t *testing.T) {
+	testCases := map[string]string{
+		"duplicate error ID": `
+errors:
+  - {id: a, message: a}
+  - {id: a, message: b}
+`,
+		"unknown tag": `
+errors:
+  - {id: a, message: a, tags: [missing]}
+`,
+		"unknown key": `
+errors:
+  - {id: a, message: a, values: [missing]}
+`,
+		"duplicate Go identifier": `
+errors:
+  - {id: user_id, message: a}
+  - {id: user-id, message: b}
+`,
+		"field": `
+errors:
+  - {id: a, message: a, unknown: field}
+`,
+	}
+
+	for expected, input := range testCases {
+		t.Run(expected, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "errors.yaml")
+			if err := os.WriteFile(path, []byte(input), 0600); err != nil {
+				t.Fatal(err)
+			}
+			_, err := loadCatalog(path)
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			var goErr *goerr.Error
+			if !errors.As(err, &goErr) || !strings.Contains(err.Error(), expected) {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func writeCatalog(t *testing.T, data string) *catalog {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "errors.yaml")
+	if err := os.WriteFile(path, []byte(data), 0600); err != nil {
+		t.Fatal(err)
+	}
+	c, err := loadCatalog(path)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	return c
+}
@@ -0,0 +1,276 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/m-mizutani/goerr/v2"
+)
+
+// errorGroup is errors that have the same fingerprint.
+type errorGroup struct {
+	Fingerprint  string         `json:"fingerprint"`
+	Count        int            `json:"count"`
+	FirstSeen    *time.Time     `json:"first_seen,omitempty"`
+	LastSeen     *time.Time     `json:"last_seen,omitempty"`
+	ID           string         `json:"id,omitempty"`
+	Tags         []string       `json:"tags"`
+	Origin       []string       `json:"origin"`
+	Message      string         `json:"message"`
+	SampleValues map[string]any `json:"sample_values"`
+	Cardinality  map[string]int `json:"cardinality"`
+
+	distinct map[string]map[string]struct{}
+}
+
+func runAggregate(args []string, stdin io.Reader, stdout io.Writer) error {
+	flags := flag.NewFlagSet("goerr aggregate", flag.ContinueOnError)
+	format := flags.String("format", "text", "output format: text, csv or json")
+	top := flags.Int("top", 0, "number of groups to output (0 is unlimited)")
+	frames := flags.Int("frames", 0, "number of origin stack frames used for fingerprint (0 is all)")
+	if err := flags.Parse(args); err != nil {
+		return goerr.Wrap(err, "failed to parse flags")
+	}
+
+	agg := newAggregator(*frames)
+	if err := readInputs(flags.Args(), stdin, func(r *record) error {
+		agg.add(r)
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	groups := agg.groups()
+	if *top > 0 && len(groups) > *top {
+		groups = groups[:*top]
+	}
+
+	switch *format {
+	case "text":
+		return writeAggregateText(stdout, groups)
+	case "csv":
+		return writeAggregateCSV(stdout, groups)
+	case "json":
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(groups); err != nil {
+			return goerr.Wrap(err, "failed to write JSON")
+		}
+		return nil
+	default:
+		return goerr.New("invalid -format value, must be text, csv or json", goerr.V("format", *format))
+	}
+}
+
+// aggregator groups errors in records by fingerprint. Records are added one by one so that whole input is not kept in memory.
+type aggregator struct {
+	frames int
+	index  map[string]*errorGroup
+	list   []*errorGroup
+}
+
+func newAggregator(frames int) *aggregator {
+	return &aggregator{frames: frames, index: make(map[string]*errorGroup)}
+}
+
+func (a *aggregator) add(r *record) {
+	ts := recordTime(r.value)
+	for _, found := range r.errors {
+		e := found.err
+		origin := originFuncs(e, a.frames)
+		tags := e.mergedTags()
+		fp := fingerprint(e.id(), tags, origin)
+
+		g, ok := a.index[fp]
+		if !ok {
+			g = &errorGroup{
+				Fingerprint:  fp,
+				ID:           e.id(),
+				Tags:         tags,
+				Origin:       origin,
+				Message:      e.fullMessage(),
+				SampleValues: e.mergedValues(),
+				Cardinality:  make(map[string]int),
+				distinct:     make(map[string]map[string]struct{}),
+			}
+			a.index[fp] = g
+			a.list = append(a.list, g)
+		}
+		g.add(e, ts)
+	}
+}
+
+// groups returns groups sorted by count in descending order, then by first seen time.
+func (a *aggregator) groups() []*errorGroup {
+	groups := append([]*errorGroup(nil), a.list...)
+	sort.SliceStable(groups, func(i, j int) bool {
+		if groups[i].Count != groups[j].Count {
+			return groups[i].Count > groups[j].Count
+		}
+		if groups[i].FirstSeen != nil && groups[j].FirstSeen != nil {
+			return groups[i].FirstSeen.Before(*groups[j].FirstSeen)
+		}
+		return groups[i].FirstSeen != nil
+	})
+
+	return groups
+}
+
+func (g *errorGroup) add(e *errorObject, ts *time.Time) {
+	g.Count++
+
+	if ts != nil {
+		if g.FirstSeen == nil || ts.Before(*g.FirstSeen) {
+			g.FirstSeen = ts
+		}
+		if g.LastSeen == nil || ts.After(*g.LastSeen) {
+			g.LastSeen = ts
+		}
+	}
+
+	for k, v := range e.mergedValues() {
+		raw, err := json.Marshal(v)
+		if err != nil {
+			raw = []byte(fmt.Sprint(v))
+		}
+		if g.distinct[k] == nil {
+			g.distinct[k] = make(map[string]struct{})
+		}
+		g.distinct[k][string(raw)] = struct{}{}
+		g.Cardinality[k] = len(g.distinct[k])
+	}
+}
+
+// fingerprint returns a short hash of ID, tags and function names of origin stack trace. Values and messages are not included because they usually contain variable data.
+func fingerprint(id string, tags, origin []string) string {
+	h := sha256.New()
+	_, _ = io.WriteString(h, id+"\x00"+strings.Join(tags, ",")+"\x00"+strings.Join(origin, "\n"))
+	return hex.EncodeToString(h.Sum(nil))[:12]
+}
+
+// originFuncs returns function names of the innermost stack trace. If frames is positive, only the first frames are returned.
+func originFuncs(e *errorObject, frames int) []string {
+	st := e.originStack()
+	if frames > 0 && len(st) > frames {
+		st = st[:frames]
+	}
+
+	funcs := make([]string, len(st))
+	for i, f := range st {
+		funcs[i] = f.Func
+	}
+	return funcs
+}
+
+// recordTime returns "time" of a slog record.
+func recordTime(v any) *time.Time {
+	m, ok := v.(map[string]any)
+	if !ok {
+		return nil
+	}
+	s, ok := m["time"].(string)
+	if !ok {
+		return nil
+	}
+	ts, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return nil
+	}
+	return &ts
+}
+
+// formatGroupValues returns sample values with number of distinct values, e.g. "user_id=u1 (3 distinct)".
+func formatGroupValues(g *errorGroup) []string {
+	keys := make([]string, 0, len(g.Cardinality))
+	for k := range g.Cardinality {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	values := make([]string, len(keys))
+	for i, k := range keys {
+		sample := "-"
+		if v, ok := g.SampleValues[k]; ok {
+			sample = fmt.Sprint(v)
+		}
+		values[i] = fmt.Sprintf("%s=%s (%d distinct)", k, sample, g.Cardinality[k])
+	}
+	return values
+}
+
+func formatTime(ts *time.Time) string {
+	if ts == nil {
+		return ""
+	}
+	return ts.Format(time.RFC3339Nano)
+}
+
+func writeAggregateText(w io.Writer, groups []*errorGroup) error {
+	var b strings.Builder
+	for i, g := range groups {
+		fmt.Fprintf(&b, "#%d count=%d fingerprint=%s\n", i+1, g.Count, g.Fingerprint)
+		fmt.Fprintf(&b, "  message:    %s\n", g.Message)
+		if g.ID != "" {
+			fmt.Fprintf(&b, "  id:         %s\n", g.ID)
+		}
+		if len(g.Tags) > 0 {
+			fmt.Fprintf(&b, "  tags:       %s\n", strings.Join(g.Tags, ", "))
+		}
+		if len(g.Origin) > 0 {
+			fmt.Fprintf(&b, "  origin:     %s\n", g.Origin[0])
+		}
+		if g.FirstSeen != nil {
+			fmt.Fprintf(&b, "  first seen: %s\n", formatTime(g.FirstSeen))
+			fmt.Fprintf(&b, "  last seen:  %s\n", formatTime(g.LastSeen))
+		}
+		for j, v := range formatGroupValues(g) {
+			label := "  values:     "
+			if j > 0 {
+				label = "              "
+			}
+			b.WriteString(label + v + "\n")
+		}
+		b.WriteString("\n")
+	}
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return goerr.Wrap(err, "failed to write output")
+	}
+	return nil
+}
+
+func writeAggregateCSV(w io.Writer, groups []*errorGroup) error {
+	cw := csv.NewWriter(w)
+	rows := [][]string{{"fingerprint", "count", "first_seen", "last_seen", "id", "tags", "origin", "message", "values"}}
+	for _, g := range groups {
+		origin := ""
+		if len(g.Origin) > 0 {
+			origin = g.Origin[0]
+		}
+		rows = append(rows, []string{
+			g.Fingerprint,
+			strconv.Itoa(g.Count),
+			formatTime(g.FirstSeen),
+			formatTime(g.LastSeen),
+			g.ID,
+			strings.Join(g.Tags, " "),
+			origin,
+			g.Message,
+			strings.Join(formatGroupValues(g), "; "),
+		})
+	}
+
+	if err := cw.WriteAll(rows); err != nil {
+		return goerr.Wrap(err, "failed to write CSV")
+	}
+	return nil
+}
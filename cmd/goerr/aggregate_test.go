@@ -0,0 +1,118 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func aggregateFile(t *testing.T, file string, frames int) []*errorGroup {
+	t.Helper()
+	agg := newAggregator(frames)
+	if err := readInputs([]string{file}, nil, func(r *record) error {
+		agg.add(r)
+		return nil
+	}); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	return agg.groups()
+}
+
+func TestAggregate(t *testing.T) {
+	groups := aggregateFile(t, "testdata/incidents.log", 0)
+	if len(groups) != 3 {
+		t.Fatalf("Expected 3 groups, got %d", len(groups))
+	}
+
+	g := groups[0]
+	if g.Count != 3 || g.Message != "failed to get user: i/o timeout" {
+		t.Errorf("Unexpected top group: %+v", g)
+	}
+	if !g.FirstSeen.Equal(time.Date(2026, 10, 18, 9, 0, 3, 0, time.UTC)) || !g.LastSeen.Equal(time.Date(2026, 10, 18, 9, 10, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected first/last seen: %v, %v", g.FirstSeen, g.LastSeen)
+	}
+	if g.Cardinality["user_id"] != 3 || g.SampleValues["user_id"] != "u1" {
+		t.Errorf("Unexpected values: %v, %v", g.SampleValues, g.Cardinality)
+	}
+
+	// Same count is ordered by first seen
+	if groups[1].Message != "failed to load config" {
+		t.Errorf("Unexpected second group: %+v", groups[1])
+	}
+
+	// Fingerprint by the first frame only merges errors from different callers
+	groups = aggregateFile(t, "testdata/incidents.log", 1)
+	if len(groups) != 2 || groups[0].Count != 4 || groups[0].Cardinality["user_id"] != 3 {
+		t.Errorf("Unexpected groups with 1 frame: %+v", groups)
+	}
+}
+
+func TestAggregateFormat(t *testing.T) {
+	t.Run("text", func(t *testing.T) {
+		var out bytes.Buffer
+		if err := run([]string{"aggregate", "-top", "1", "testdata/incidents.log"}, nil, &out); err != nil {
+			t.Fatalf("%+v", err)
+		}
+		for _, s := range []string{"#1 count=3 ", "  origin:     example.com/app/repo.GetUser\n", "  values:     user_id=u1 (3 distinct)\n"} {
+			if !strings.Contains(out.String(), s) {
+				t.Errorf("Expected output to contain %q:\n%s", s, out.String())
+			}
+		}
+		if strings.Contains(out.String(), "#2") {
+			t.Errorf("Expected only top 1 group:\n%s", out.String())
+		}
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		var out bytes.Buffer
+		if err := run([]string{"aggregate", "-format", "csv", "testdata/incidents.log"}, nil, &out); err != nil {
+			t.Fatalf("%+v", err)
+		}
+		rows, err := csv.NewReader(&out).ReadAll()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(rows) != 4 || rows[0][1] != "count" || rows[1][1] != "3" || rows[1][5] != "db" {
+			t.Errorf("Unexpected CSV: %v", rows)
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		var out bytes.Buffer
+		if err := run([]string{"aggregate", "-format", "json", "testdata/incidents.log"}, nil, &out); err != nil {
+			t.Fatalf("%+v", err)
+		}
+		var groups []map[string]any
+		if err := json.Unmarshal(out.Bytes(), &groups); err != nil {
+			t.Fatal(err)
+		}
+		if len(groups) != 3 || groups[0]["count"] != float64(3) || groups[0]["first_seen"] != "2026-10-18T09:00:03Z" {
+			t.Errorf("Unexpected JSON: %s", out.String())
+		}
+	})
+
+	t.Run("invalid format", func(t *testing.T) {
+		if err := run([]string{"aggregate", "-format", "xml", "testdata/incidents.log"}, nil, &bytes.Buffer{}); err == nil {
+			t.Error("Expected error for invalid format")
+		}
+	})
+}
+
+func TestFingerprint(t *testing.T) {
+	base := fingerprint("id", []string{"a"}, []string{"f1", "f2"})
+	if base != fingerprint("id", []string{"a"}, []string{"f1", "f2"}) {
+		t.Error("Fingerprint should be stable")
+	}
+	for _, fp := range []string{
+		fingerprint("other", []string{"a"}, []string{"f1", "f2"}),
+		fingerprint("id", []string{"b"}, []string{"f1", "f2"}),
+		fingerprint("id", []string{"a"}, []string{"f1"}),
+	} {
+		if fp == base {
+			t.Error("Fingerprint should differ")
+		}
+	}
+}
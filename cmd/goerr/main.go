@@ -0,0 +1,73 @@
+// Command goerr is a toolkit for errors of github.com/m-mizutani/goerr/v2 in logs.
+//
+// Usage:
+//
+//	goerr pretty [flags] [file ...]
+//	goerr aggregate [flags] [file ...]
+//	goerr symbolize -binary <path> [flags] [file ...]
+//
+// pretty reads JSON or JSON Lines from files or stdin, finds goerr errors (JSON of *goerr.Error, *goerr.Errors, and slog output of them) and renders them in the same layout as fmt.Printf("%+v", err). Lines without goerr errors are printed as is.
+//
+//	kubectl logs deploy/app | goerr pretty -exclude-frame '^(runtime|net/http)\.'
+//
+// aggregate groups goerr errors in JSON logs by fingerprint of ID, tags and function names of the origin stack trace, and outputs a report ranked by count as text, CSV or JSON.
+//
+//	goerr aggregate -format csv -top 20 app-*.log
+//
+// symbolize resolves raw stack traces emitted with goerr.SetDeferredSymbolization(true) into function names, files and lines by the symbol table of the binary that emitted the logs. Build ID in the logs must match the binary.
+//
+//	goerr symbolize -binary ./bin/app app.log | goerr pretty
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/m-mizutani/goerr/v2"
+)
+
+// command is a subcommand of goerr.
+type command struct {
+	name  string
+	usage string
+	run   func(args []string, stdin io.Reader, stdout io.Writer) error
+}
+
+var commands = []*command{
+	{name: "pretty", usage: "pretty-print goerr errors in JSON logs", run: runPretty},
+	{name: "aggregate", usage: "group goerr errors in JSON logs by fingerprint", run: runAggregate},
+	{name: "symbolize", usage: "resolve raw stack traces in JSON logs with the binary", run: runSymbolize},
+}
+
+func main() {
+	if err := run(os.Args[1:], os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "goerr: %+v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, stdin io.Reader, stdout io.Writer) error {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "-help" || args[0] == "help" {
+		printUsage(stdout)
+		return nil
+	}
+
+	for _, cmd := range commands {
+		if cmd.name == args[0] {
+			return cmd.run(args[1:], stdin, stdout)
+		}
+	}
+
+	printUsage(os.Stderr)
+	return goerr.New("unknown command", goerr.V("command", args[0]))
+}
+
+func printUsage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: goerr <command> [flags] [file ...]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-10s %s\n", cmd.name, cmd.usage)
+	}
+}
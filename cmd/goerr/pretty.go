@@ -0,0 +1,199 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/m-mizutani/goerr/v2"
+)
+
+type prettyConfig struct {
+	color        bool
+	noStack      bool
+	excludeFrame *regexp.Regexp
+	maxFrames    int
+}
+
+func runPretty(args []string, stdin io.Reader, stdout io.Writer) error {
+	flags := flag.NewFlagSet("goerr pretty", flag.ContinueOnError)
+	color := flags.String("color", "auto", "colorize output: auto, always or never")
+	noStack := flags.Bool("no-stack", false, "hide stack traces")
+	excludeFrame := flags.String("exclude-frame", "", "hide stack frames whose function name matches the regular expression")
+	maxFrames := flags.Int("max-frames", 0, "maximum number of stack frames to print (0 is unlimited)")
+	if err := flags.Parse(args); err != nil {
+		return goerr.Wrap(err, "failed to parse flags")
+	}
+
+	cfg := &prettyConfig{
+		noStack:   *noStack,
+		maxFrames: *maxFrames,
+	}
+
+	switch *color {
+	case "auto":
+		cfg.color = isTerminal(stdout) && os.Getenv("NO_COLOR") == ""
+	case "always":
+		cfg.color = true
+	case "never":
+		cfg.color = false
+	default:
+		return goerr.New("invalid -color value, must be auto, always or never", goerr.V("color", *color))
+	}
+
+	if *excludeFrame != "" {
+		re, err := regexp.Compile(*excludeFrame)
+		if err != nil {
+			return goerr.Wrap(err, "invalid -exclude-frame pattern", goerr.V("pattern", *excludeFrame))
+		}
+		cfg.excludeFrame = re
+	}
+
+	return readInputs(flags.Args(), stdin, func(r *record) error {
+		if _, err := io.WriteString(stdout, cfg.render(r)); err != nil {
+			return goerr.Wrap(err, "failed to write output")
+		}
+		return nil
+	})
+}
+
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+const (
+	ansiReset  = "\x1b[0m"
+	ansiBold   = "\x1b[1m"
+	ansiFaint  = "\x1b[2m"
+	ansiRed    = "\x1b[31m"
+	ansiYellow = "\x1b[33m"
+	ansiCyan   = "\x1b[36m"
+)
+
+func (cfg *prettyConfig) paint(s string, codes ...string) string {
+	if !cfg.color || s == "" {
+		return s
+	}
+	return strings.Join(codes, "") + s + ansiReset
+}
+
+// render returns the human readable text of r. A record without goerr errors is returned as is.
+func (cfg *prettyConfig) render(r *record) string {
+	if len(r.errors) == 0 {
+		return string(r.raw) + "\n"
+	}
+
+	var b strings.Builder
+	if header := recordHeader(r.value); header != "" {
+		b.WriteString(cfg.paint(header, ansiBold) + "\n")
+	}
+	for _, found := range r.errors {
+		if found.path != "" {
+			b.WriteString(cfg.paint("["+found.path+"]", ansiYellow) + " ")
+		}
+		cfg.renderError(&b, found.err)
+	}
+	return b.String()
+}
+
+// recordHeader returns time, level and message of a slog record.
+func recordHeader(v any) string {
+	m, ok := v.(map[string]any)
+	if !ok {
+		return ""
+	}
+
+	var parts []string
+	for _, key := range []string{"time", "level", "msg"} {
+		if s, ok := m[key].(string); ok && s != "" {
+			parts = append(parts, s)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// renderError writes e in the layout of fmt.Printf("%+v", err) of *goerr.Error, with ID, tags and the root cause. Sections are separated by a single blank line.
+func (cfg *prettyConfig) renderError(b *strings.Builder, e *errorObject) {
+	b.WriteString(cfg.paint(e.fullMessage(), ansiBold, ansiRed))
+
+	if !cfg.noStack {
+		frames := 0
+		for _, st := range e.originStack() {
+			if cfg.excludeFrame != nil && cfg.excludeFrame.MatchString(st.Func) {
+				continue
+			}
+			if cfg.maxFrames > 0 && frames >= cfg.maxFrames {
+				b.WriteString("\n" + cfg.paint("...", ansiFaint))
+				break
+			}
+			frames++
+
+			location := st.File
+			if st.Line > 0 {
+				location = fmt.Sprintf("%s:%d", st.File, st.Line)
+			}
+			b.WriteString("\n" + cfg.paint(st.Func, ansiCyan) + "\n\t" + cfg.paint(location, ansiFaint))
+		}
+	}
+	b.WriteString("\n")
+
+	if len(e.Ops) > 0 {
+		cfg.renderSection(b, "Ops", []string{strings.Join(e.Ops, goerr.OpsSeparator)})
+	}
+	if id := e.id(); id != "" {
+		cfg.renderSection(b, "ID", []string{id})
+	}
+	if tags := e.mergedTags(); len(tags) > 0 {
+		cfg.renderSection(b, "Tags", tags)
+	}
+	cfg.renderSection(b, "Values", formatValues(e.mergedValues()))
+	cfg.renderSection(b, "Typed Values", formatValues(e.mergedTypedValues()))
+
+	if cause := e.rootCause(); cause != nil && (cause.Type != "" || len(cause.Fields) > 0) {
+		lines := []string{"message: " + cause.Message}
+		if cause.Type != "" {
+			lines = append(lines, "type: "+cause.Type)
+		}
+		for _, line := range formatValues(cause.Fields) {
+			lines = append(lines, "fields."+line)
+		}
+		cfg.renderSection(b, "Cause", lines)
+	}
+	b.WriteString("\n")
+}
+
+func (cfg *prettyConfig) renderSection(b *strings.Builder, title string, lines []string) {
+	if len(lines) == 0 {
+		return
+	}
+	b.WriteString("\n" + cfg.paint(title+":", ansiYellow) + "\n")
+	for _, line := range lines {
+		b.WriteString("  " + line + "\n")
+	}
+}
+
+func formatValues(values map[string]any) []string {
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	lines := make([]string, len(keys))
+	for i, k := range keys {
+		lines[i] = fmt.Sprintf("%s: %v", k, values[k])
+	}
+	return lines
+}
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/m-mizutani/goerr/v2"
+)
+
+func TestPretty(t *testing.T) {
+	var out bytes.Buffer
+	if err := run([]string{"pretty", "-color", "never", "testdata/app.log"}, nil, &out); err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	expected, err := os.ReadFile("testdata/app.pretty.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != string(expected) {
+		t.Errorf("Unexpected output:\n%s", out.String())
+	}
+}
+
+func TestPrettyFlags(t *testing.T) {
+	testCases := map[string]struct {
+		args     []string
+		contains []string
+		excludes []string
+	}{
+		"no stack": {
+			args:     []string{"-no-stack"},
+			contains: []string{"failed to start: failed to load config"},
+			excludes: []string{"config.Load", "runtime.goexit"},
+		},
+		"exclude frame": {
+			args:     []string{"-exclude-frame", `^(runtime|testing)\.`},
+			contains: []string{"example.com/app/config.Load\n\tconfig/config.go:21\n\nTags:"},
+			excludes: []string{"runtime.goexit", "testing.tRunner"},
+		},
+		"max frames": {
+			args:     []string{"-max-frames", "1"},
+			contains: []string{"example.com/app/config.Load\n\tconfig/config.go:21\n...\n"},
+			excludes: []string{"runtime.goexit"},
+		},
+		"color": {
+			args:     []string{"-color", "always"},
+			contains: []string{"\x1b[1m\x1b[31mfailed to start", "\x1b[33mValues:\x1b[0m"},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var out bytes.Buffer
+			args := append([]string{"pretty"}, tc.args...)
+			if err := run(append(args, "testdata/app.log"), nil, &out); err != nil {
+				t.Fatalf("%+v", err)
+			}
+			for _, s := range tc.contains {
+				if !strings.Contains(out.String(), s) {
+					t.Errorf("Expected output to contain %q:\n%s", s, out.String())
+				}
+			}
+			for _, s := range tc.excludes {
+				if strings.Contains(out.String(), s) {
+					t.Errorf("Expected output not to contain %q:\n%s", s, out.String())
+				}
+			}
+		})
+	}
+}
+
+func TestPrettyJSONDocument(t *testing.T) {
+	input := `{
+  "message": "failed",
+  "id": "",
+  "stacktrace": null,
+  "cause": "connection refused",
+  "values": {"host": "db"}
+}`
+
+	var out bytes.Buffer
+	if err := run([]string{"pretty", "-color", "never"}, strings.NewReader(input), &out); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	expected := "failed: connection refused\n\nValues:\n  host: db\n\n"
+	if out.String() != expected {
+		t.Errorf("Unexpected output:\n%q", out.String())
+	}
+}
+
+func TestPrettyStreaming(t *testing.T) {
+	inR, inW := io.Pipe()
+	outR, outW := io.Pipe()
+	done := make(chan error, 1)
+	go func() {
+		done <- run([]string{"pretty", "-color", "never", "-no-stack"}, inR, outW)
+		outW.Close()
+	}()
+	out := bufio.NewReader(outR)
+
+	// Each record is rendered before input is closed
+	if _, err := io.WriteString(inW, "starting server\n"); err != nil {
+		t.Fatal(err)
+	}
+	if line, err := out.ReadString('\n'); err != nil || line != "starting server\n" {
+		t.Fatalf("Unexpected output: %q, %v", line, err)
+	}
+
+	data, err := json.Marshal(goerr.New("failed", goerr.V("host", "db")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := inW.Write(append(data, '\n')); err != nil {
+		t.Fatal(err)
+	}
+	if line, err := out.ReadString('\n'); err != nil || line != "failed\n" {
+		t.Fatalf("Unexpected output: %q, %v", line, err)
+	}
+
+	inW.Close()
+	rest, err := io.ReadAll(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(rest) != "\nValues:\n  host: db\n\n" {
+		t.Errorf("Unexpected output: %q", rest)
+	}
+	if err := <-done; err != nil {
+		t.Errorf("%+v", err)
+	}
+}
+
+func TestPrettyIncompleteJSON(t *testing.T) {
+	input := "{\"message\": \"truncated\n{\"message\":\"failed\",\"stacktrace\":null}\n} not JSON\n"
+
+	var out bytes.Buffer
+	if err := run([]string{"pretty", "-color", "never"}, strings.NewReader(input), &out); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	expected := "{\"message\": \"truncated\nfailed\n\n} not JSON\n"
+	if out.String() != expected {
+		t.Errorf("Unexpected output:\n%q", out.String())
+	}
+}
+
+func TestPrettyOps(t *testing.T) {
+	err := goerr.Wrap(goerr.New("not found", goerr.Op("repo.Find")), "failed to get user", goerr.Op("UserService.Get"))
+	data, jsonErr := json.Marshal(err)
+	if jsonErr != nil {
+		t.Fatal(jsonErr)
+	}
+
+	var out bytes.Buffer
+	if err := run([]string{"pretty", "-color", "never", "-no-stack"}, bytes.NewReader(data), &out); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	expected := "failed to get user: not found\n\nOps:\n  UserService.Get > repo.Find\n\n"
+	if out.String() != expected {
+		t.Errorf("Unexpected output:\n%q", out.String())
+	}
+}
+
+func TestRunUnknownCommand(t *testing.T) {
+	if err := run([]string{"unknown"}, nil, &bytes.Buffer{}); err == nil {
+		t.Error("Expected error for unknown command")
+	}
+}
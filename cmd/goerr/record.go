@@ -0,0 +1,418 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/m-mizutani/goerr/v2"
+)
+
+// record is a JSON value or a line of input.
+type record struct {
+	raw    []byte
+	value  any // nil if raw is not JSON
+	errors []*foundError
+}
+
+// foundError is a goerr error found in a record. path is location of the error in the record, e.g. "error" for slog attribute. It is empty if the record itself is the error.
+type foundError struct {
+	path string
+	err  *errorObject
+}
+
+// errorObject is a decoded goerr error. It is built from JSON of Printable (json.Marshal of *goerr.Error), ErrorsJSON (*goerr.Errors), PrintableCause, or slog output of (*goerr.Error).LogValue.
+type errorObject struct {
+	Message     string
+	ID          string
+	Type        string
+	Ops         []string
+	Stack       []stackFrame
+	Values      map[string]any
+	TypedValues map[string]any
+	Tags        []string
+	Fields      map[string]any
+	Cause       *errorObject
+	isGoerr     bool // false for PrintableCause and plain error messages
+}
+
+type stackFrame struct {
+	Func string
+	File string
+	Line int
+}
+
+// readInputs reads records from files and calls fn for each record as soon as it is read. If files is empty or a file is "-", stdin is read.
+func readInputs(files []string, stdin io.Reader, fn func(*record) error) error {
+	if len(files) == 0 {
+		files = []string{"-"}
+	}
+
+	for _, file := range files {
+		if err := readInput(file, stdin, fn); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func readInput(file string, stdin io.Reader, fn func(*record) error) error {
+	r := stdin
+	if file != "-" {
+		f, err := os.Open(file)
+		if err != nil {
+			return goerr.Wrap(err, "failed to open input", goerr.V("file", file))
+		}
+		defer f.Close()
+		r = f
+	}
+
+	if err := parseRecords(r, fn); err != nil {
+		return goerr.Wrap(err, "failed to read input", goerr.V("file", file))
+	}
+	return nil
+}
+
+// parseRecords reads r line by line and calls fn for each record. A line is a record of JSON value, such as a line of JSON Lines, or a record of plain text. A JSON value across lines, such as an indented JSON document, is buffered until it is complete, and lines of an invalid JSON value are parsed line by line.
+func parseRecords(r io.Reader, fn func(*record) error) error {
+	var pending []byte
+	flush := func() error {
+		if len(pending) == 0 {
+			return nil
+		}
+		for _, line := range bytes.Split(bytes.TrimSuffix(pending, []byte("\n")), []byte("\n")) {
+			if err := fn(parseLine(line)); err != nil {
+				return err
+			}
+		}
+		pending = nil
+		return nil
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(pending) == 0 && !startsJSON(line) {
+			if err := fn(parseLine(line)); err != nil {
+				return err
+			}
+			continue
+		}
+
+		pending = append(append(pending, line...), '\n')
+		records, err := parseJSONStream(pending)
+		switch {
+		case errors.Is(err, io.ErrUnexpectedEOF):
+			// JSON value continues to next lines
+			continue
+		case err != nil:
+			if err := flush(); err != nil {
+				return err
+			}
+			continue
+		}
+
+		pending = nil
+		for _, record := range records {
+			if err := fn(record); err != nil {
+				return err
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return goerr.Wrap(err, "failed to scan input")
+	}
+
+	return flush()
+}
+
+// startsJSON returns true if line begins with a JSON object or array.
+func startsJSON(line []byte) bool {
+	trimmed := bytes.TrimLeft(line, " \t\r")
+	return len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[')
+}
+
+// parseLine returns a record of line. Goerr errors are searched if line is a JSON value.
+func parseLine(line []byte) *record {
+	r := &record{raw: append([]byte(nil), line...)}
+	if v, err := decodeJSON(line); err == nil {
+		r.value = v
+		r.errors = findErrors(v, "")
+	}
+	return r
+}
+
+// parseJSONStream parses data as a stream of JSON values. It returns io.ErrUnexpectedEOF if the last value is incomplete.
+func parseJSONStream(data []byte) ([]*record, error) {
+	var records []*record
+	dec := json.NewDecoder(bytes.NewReader(data))
+	for {
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); errors.Is(err, io.EOF) {
+			return records, nil
+		} else if err != nil {
+			return nil, err
+		}
+
+		v, err := decodeJSON(raw)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, &record{raw: raw, value: v, errors: findErrors(v, "")})
+	}
+}
+
+func decodeJSON(data []byte) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// findErrors returns goerr errors in v. Errors in an error object are not searched.
+func findErrors(v any, path string) []*foundError {
+	switch v := v.(type) {
+	case map[string]any:
+		if isErrorObject(v) {
+			return []*foundError{{path: path, err: parseErrorObject(v)}}
+		}
+		if elems, ok := errorsElements(v); ok {
+			var found []*foundError
+			for i, elem := range elems {
+				p := fmt.Sprintf("%s[%d]", joinPath(path, "errors"), i)
+				switch elem := elem.(type) {
+				case map[string]any:
+					found = append(found, &foundError{path: p, err: parseErrorObject(elem)})
+				case string:
+					found = append(found, &foundError{path: p, err: &errorObject{Message: elem}})
+				}
+			}
+			return found
+		}
+
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		var found []*foundError
+		for _, k := range keys {
+			found = append(found, findErrors(v[k], joinPath(path, k))...)
+		}
+		return found
+
+	case []any:
+		var found []*foundError
+		for i, elem := range v {
+			found = append(found, findErrors(elem, fmt.Sprintf("%s[%d]", path, i))...)
+		}
+		return found
+	}
+
+	return nil
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// isErrorObject returns true if m has the schema of Printable or slog output of *goerr.Error.
+func isErrorObject(m map[string]any) bool {
+	if _, ok := m["message"].(string); !ok {
+		return false
+	}
+	_, ok := m["stacktrace"]
+	return ok
+}
+
+// errorsElements returns elements of m if m has the schema of ErrorsJSON and contains at least one goerr error.
+func errorsElements(m map[string]any) ([]any, bool) {
+	for key := range m {
+		switch key {
+		case "errors", "overflow", "counts":
+		default:
+			return nil, false
+		}
+	}
+	elems, ok := m["errors"].([]any)
+	if !ok {
+		return nil, false
+	}
+	for _, elem := range elems {
+		if e, ok := elem.(map[string]any); ok && isErrorObject(e) {
+			return elems, true
+		}
+	}
+	return nil, false
+}
+
+var slogFramePattern = regexp.MustCompile(`^(.*):(\d+) (\S+)$`)
+
+func parseErrorObject(m map[string]any) *errorObject {
+	e := &errorObject{
+		Values:      toMap(m["values"]),
+		TypedValues: toMap(m["typed_values"]),
+		Fields:      toMap(m["fields"]),
+		isGoerr:     isErrorObject(m),
+	}
+	e.Message, _ = m["message"].(string)
+	e.ID, _ = m["id"].(string)
+	e.Type, _ = m["type"].(string)
+
+	if tags, ok := m["tags"].([]any); ok {
+		for _, t := range tags {
+			if s, ok := t.(string); ok {
+				e.Tags = append(e.Tags, s)
+			}
+		}
+	}
+	if ops, ok := m["ops"].([]any); ok {
+		for _, op := range ops {
+			if s, ok := op.(string); ok {
+				e.Ops = append(e.Ops, s)
+			}
+		}
+	}
+
+	if frames, ok := m["stacktrace"].([]any); ok {
+		for _, f := range frames {
+			switch f := f.(type) {
+			case map[string]any:
+				// Printable: {"func": ..., "file": ..., "line": ...}
+				st := stackFrame{}
+				st.Func, _ = f["func"].(string)
+				st.File, _ = f["file"].(string)
+				if n, ok := f["line"].(json.Number); ok {
+					line, _ := strconv.Atoi(n.String())
+					st.Line = line
+				}
+				e.Stack = append(e.Stack, st)
+			case string:
+				// slog: "file:line func"
+				if match := slogFramePattern.FindStringSubmatch(f); match != nil {
+					line, _ := strconv.Atoi(match[2])
+					e.Stack = append(e.Stack, stackFrame{Func: match[3], File: match[1], Line: line})
+				} else {
+					e.Stack = append(e.Stack, stackFrame{Func: f})
+				}
+			}
+		}
+	}
+
+	switch cause := m["cause"].(type) {
+	case map[string]any:
+		e.Cause = parseErrorObject(cause)
+	case string:
+		e.Cause = &errorObject{Message: cause}
+	}
+
+	return e
+}
+
+func toMap(v any) map[string]any {
+	m, _ := v.(map[string]any)
+	return m
+}
+
+// chain returns e and its causes from outermost to innermost.
+func (e *errorObject) chain() []*errorObject {
+	var chain []*errorObject
+	for c := e; c != nil; c = c.Cause {
+		chain = append(chain, c)
+	}
+	return chain
+}
+
+// fullMessage returns the message in the same way as (*goerr.Error).Error.
+func (e *errorObject) fullMessage() string {
+	if e.Cause == nil {
+		return e.Message
+	}
+	if e.Message == "" {
+		return e.Cause.fullMessage()
+	}
+	return e.Message + ": " + e.Cause.fullMessage()
+}
+
+// originStack returns the innermost stack trace.
+func (e *errorObject) originStack() []stackFrame {
+	var st []stackFrame
+	for _, c := range e.chain() {
+		if len(c.Stack) > 0 {
+			st = c.Stack
+		}
+	}
+	return st
+}
+
+// id returns the outermost ID in the chain.
+func (e *errorObject) id() string {
+	for _, c := range e.chain() {
+		if c.ID != "" {
+			return c.ID
+		}
+	}
+	return ""
+}
+
+// mergedValues returns values merged from innermost to outermost. Values of Printable are already merged, while values of slog output are not.
+func (e *errorObject) mergedValues() map[string]any {
+	return e.merge(func(c *errorObject) map[string]any { return c.Values })
+}
+
+func (e *errorObject) mergedTypedValues() map[string]any {
+	return e.merge(func(c *errorObject) map[string]any { return c.TypedValues })
+}
+
+func (e *errorObject) merge(get func(*errorObject) map[string]any) map[string]any {
+	merged := make(map[string]any)
+	chain := e.chain()
+	for i := len(chain) - 1; i >= 0; i-- {
+		for k, v := range get(chain[i]) {
+			merged[k] = v
+		}
+	}
+	return merged
+}
+
+func (e *errorObject) mergedTags() []string {
+	set := make(map[string]struct{})
+	for _, c := range e.chain() {
+		for _, t := range c.Tags {
+			set[t] = struct{}{}
+		}
+	}
+
+	tags := make([]string, 0, len(set))
+	for t := range set {
+		tags = append(tags, t)
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// rootCause returns the innermost error if it is not goerr error, e.g. PrintableCause.
+func (e *errorObject) rootCause() *errorObject {
+	chain := e.chain()
+	last := chain[len(chain)-1]
+	if len(chain) > 1 && !last.isGoerr {
+		return last
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"debug/elf"
+	"debug/gosym"
+	"encoding/json"
+	"flag"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/m-mizutani/goerr/v2"
+	"github.com/m-mizutani/goerr/v2/internal/buildid"
+)
+
+// anchorSymbol is the function whose runtime address is recorded as anchor of goerr.RawStack.
+const anchorSymbol = "github.com/m-mizutani/goerr/v2.callers"
+
+// symbolizer resolves program counters with the symbol table of a binary.
+type symbolizer struct {
+	buildID string
+	table   *gosym.Table
+	anchor  uint64
+}
+
+func runSymbolize(args []string, stdin io.Reader, stdout io.Writer) error {
+	flags := flag.NewFlagSet("goerr symbolize", flag.ContinueOnError)
+	binary := flags.String("binary", "", "ELF binary that emitted the logs (required)")
+	force := flags.Bool("force", false, "symbolize even if build ID does not match")
+	if err := flags.Parse(args); err != nil {
+		return goerr.Wrap(err, "failed to parse flags")
+	}
+	if *binary == "" {
+		return goerr.New("-binary is required")
+	}
+
+	sym, err := newSymbolizer(*binary)
+	if err != nil {
+		return err
+	}
+
+	return readInputs(flags.Args(), stdin, func(r *record) error {
+		line := r.raw
+		if r.value != nil {
+			changed, err := sym.resolve(r.value, *force)
+			if err != nil {
+				return err
+			}
+			if changed {
+				if line, err = json.Marshal(r.value); err != nil {
+					return goerr.Wrap(err, "failed to marshal record")
+				}
+			}
+		}
+
+		if _, err := stdout.Write(append(line, '\n')); err != nil {
+			return goerr.Wrap(err, "failed to write output")
+		}
+		return nil
+	})
+}
+
+func newSymbolizer(path string) (*symbolizer, error) {
+	f, err := elf.Open(path)
+	if err != nil {
+		return nil, goerr.Wrap(err, "failed to open ELF binary", goerr.V("path", path))
+	}
+	defer f.Close()
+
+	sec := f.Section(".gopclntab")
+	text := f.Section(".text")
+	if sec == nil || text == nil {
+		return nil, goerr.New("no Go symbol table in binary", goerr.V("path", path))
+	}
+	pclntab, err := sec.Data()
+	if err != nil {
+		return nil, goerr.Wrap(err, "failed to read .gopclntab", goerr.V("path", path))
+	}
+
+	table, err := gosym.NewTable(nil, gosym.NewLineTable(pclntab, text.Addr))
+	if err != nil {
+		return nil, goerr.Wrap(err, "failed to parse symbol table", goerr.V("path", path))
+	}
+
+	anchor := table.LookupFunc(anchorSymbol)
+	if anchor == nil {
+		return nil, goerr.New("binary is not linked with goerr", goerr.V("path", path), goerr.V("symbol", anchorSymbol))
+	}
+
+	return &symbolizer{
+		buildID: buildid.ReadFile(path),
+		table:   table,
+		anchor:  anchor.Entry,
+	}, nil
+}
+
+// resolve replaces raw_stack of goerr errors in v with stacktrace. It returns true if v is changed.
+func (x *symbolizer) resolve(v any, force bool) (bool, error) {
+	changed := false
+	switch v := v.(type) {
+	case map[string]any:
+		if raw, ok := v["raw_stack"].(map[string]any); ok {
+			frames, err := x.frames(raw, force)
+			if err != nil {
+				return false, err
+			}
+			v["stacktrace"] = frames
+			delete(v, "raw_stack")
+			changed = true
+		}
+		for _, child := range v {
+			c, err := x.resolve(child, force)
+			if err != nil {
+				return false, err
+			}
+			changed = changed || c
+		}
+
+	case []any:
+		for _, child := range v {
+			c, err := x.resolve(child, force)
+			if err != nil {
+				return false, err
+			}
+			changed = changed || c
+		}
+	}
+
+	return changed, nil
+}
+
+// frames returns stack frames in the format of goerr.Stack.
+func (x *symbolizer) frames(raw map[string]any, force bool) ([]any, error) {
+	buildID, _ := raw["build_id"].(string)
+	if !force && buildID != "" && x.buildID != "" && buildID != x.buildID {
+		return nil, goerr.New("build ID mismatch, use -force to symbolize anyway",
+			goerr.V("log", buildID), goerr.V("binary", x.buildID))
+	}
+
+	// Offset between runtime address and link-time address, non-zero for position independent executables
+	var slide uint64
+	if anchor, err := parseHex(raw["anchor"]); err == nil {
+		slide = anchor - x.anchor
+	}
+
+	pcs, _ := raw["pcs"].([]any)
+	frames := make([]any, 0, len(pcs))
+	for _, v := range pcs {
+		pc, err := parseHex(v)
+		if err != nil {
+			return nil, goerr.Wrap(err, "invalid program counter", goerr.V("pc", v))
+		}
+
+		// For a PC in an inlined call, PCToLine returns file and line of the inlined function but the physical function that the call is inlined into, because gosym does not read inline tree of the binary. So function names of inlined frames may be wrong.
+		file, line, fn := x.table.PCToLine(pc - slide)
+		name := "unknown"
+		if fn != nil {
+			name = fn.Name
+		}
+		frames = append(frames, map[string]any{"func": name, "file": file, "line": line})
+	}
+
+	return frames, nil
+}
+
+func parseHex(v any) (uint64, error) {
+	s, _ := v.(string)
+	n, err := strconv.ParseUint(strings.TrimPrefix(s, "0x"), 16, 64)
+	if err != nil {
+		return 0, goerr.Wrap(err, "invalid hex number", goerr.V("value", v))
+	}
+	return n, nil
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/m-mizutani/goerr/v2"
+)
+
+func newRawStackError() error {
+	return goerr.New("failed", goerr.V("k", "v"))
+}
+
+func TestSymbolize(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("symbolize supports only ELF binaries")
+	}
+
+	goerr.SetDeferredSymbolization(true)
+	t.Cleanup(func() { goerr.SetDeferredSymbolization(false) })
+
+	raw, err := json.Marshal(goerr.Wrap(newRawStackError(), "wrapped"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(raw, []byte(`"raw_stack"`)) || bytes.Contains(raw, []byte("newRawStackError")) {
+		t.Fatalf("Expected unresolved stack trace: %s", raw)
+	}
+
+	input := filepath.Join(t.TempDir(), "app.log")
+	if err := os.WriteFile(input, append(raw, '\n'), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	binary, err := os.Executable()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if err := run([]string{"symbolize", "-binary", binary, input}, nil, &out); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if strings.Contains(out.String(), "raw_stack") {
+		t.Errorf("Expected raw_stack to be resolved: %s", out.String())
+	}
+
+	var records []*record
+	if err := parseRecords(&out, func(r *record) error {
+		records = append(records, r)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 1 || len(records[0].errors) != 1 {
+		t.Fatalf("Unexpected records: %s", out.String())
+	}
+	origin := records[0].errors[0].err.originStack()
+	if len(origin) == 0 || origin[0].Func != "github.com/m-mizutani/goerr/v2/cmd/goerr.newRawStackError" || filepath.Base(origin[0].File) != "symbolize_test.go" || origin[0].Line != 16 {
+		t.Errorf("Unexpected origin: %+v", origin)
+	}
+}
+
+func TestSymbolizeBuildIDMismatch(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("symbolize supports only ELF binaries")
+	}
+
+	binary, err := os.Executable()
+	if err != nil {
+		t.Fatal(err)
+	}
+	input := `{"message":"failed","stacktrace":null,"raw_stack":{"build_id":"other","anchor":"0x0","pcs":[]}}`
+
+	if err := run([]string{"symbolize", "-binary", binary}, strings.NewReader(input), &bytes.Buffer{}); err == nil {
+		t.Error("Expected error for build ID mismatch")
+	}
+	if err := run([]string{"symbolize", "-binary", binary, "-force"}, strings.NewReader(input), &bytes.Buffer{}); err != nil {
+		t.Errorf("Unexpected error with -force: %+v", err)
+	}
+}
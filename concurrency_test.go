@@ -0,0 +1,87 @@
+package goerr_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/m-mizutani/goerr/v2"
+)
+
+// These tests are designed to be run with -race option.
+
+var errSharedSentinel = goerr.New("shared sentinel", goerr.ID("shared"), goerr.V("base", "value"))
+
+func TestConcurrentSentinel(t *testing.T) {
+	tag := goerr.NewTag("concurrent")
+	key := goerr.NewTypedKey[int]("index")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 32; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			wrapped := errSharedSentinel.Wrap(fmt.Errorf("cause %d", i), goerr.V("i", i))
+			with := goerr.With(errSharedSentinel, goerr.T(tag), goerr.TV(key, i), goerr.Unstack(1))
+			outer := goerr.Wrap(with, "outer", goerr.V("outer", i))
+
+			if !errors.Is(wrapped, errSharedSentinel) || !errors.Is(outer, errSharedSentinel) {
+				t.Error("Expected to match sentinel")
+			}
+			if v := outer.Values(); v["outer"] != i || v["base"] != "value" {
+				t.Errorf("Unexpected values: %v", v)
+			}
+			if v, ok := goerr.GetTypedValue(outer, key); !ok || v != i {
+				t.Errorf("Unexpected typed value: %v", v)
+			}
+			if !outer.HasTag(tag) {
+				t.Error("Expected tag")
+			}
+			if _, err := json.Marshal(outer); err != nil {
+				t.Error(err)
+			}
+			_ = fmt.Sprintf("%+v", outer)
+			_ = outer.LogValue()
+		}(i)
+	}
+	wg.Wait()
+
+	if len(errSharedSentinel.Values()) != 1 || len(errSharedSentinel.Tags()) != 0 {
+		t.Errorf("Sentinel error was modified: %v %v", errSharedSentinel.Values(), errSharedSentinel.Tags())
+	}
+}
+
+func TestConcurrentBuilder(t *testing.T) {
+	options := make([]goerr.Option, 0, 16)
+	options = append(options, goerr.V("service", "auth"))
+	base := goerr.NewBuilder(options...)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 32; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			id := strconv.Itoa(i)
+			b := base.With(goerr.V("id", id))
+			err1 := b.New("new", goerr.V("kind", "new"))
+			err2 := b.Wrap(errSharedSentinel, "wrap")
+			err3 := base.New("base")
+
+			if v := err1.Values(); v["id"] != id || v["service"] != "auth" || v["kind"] != "new" {
+				t.Errorf("Unexpected values: %v", v)
+			}
+			if v := err2.Values(); v["id"] != id || v["base"] != "value" {
+				t.Errorf("Unexpected values: %v", v)
+			}
+			if v := err3.Values(); len(v) != 1 {
+				t.Errorf("Unexpected values: %v", v)
+			}
+		}(i)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,96 @@
+package goerr
+
+import (
+	"context"
+	"sync"
+)
+
+type builderCtxKey struct{}
+
+// ContextWithBuilder returns a copy of ctx that carries b. Errors created by NewCtx and WrapCtx with the returned context have options of b. If ctx already carries a Builder, the carried Builder has options of both, and options of b are applied later.
+//
+// Usage:
+//
+//	func middleware(next http.Handler) http.Handler {
+//		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+//			b := goerr.NewBuilder(goerr.V("request_id", r.Header.Get("X-Request-ID")))
+//			next.ServeHTTP(w, r.WithContext(goerr.ContextWithBuilder(r.Context(), b)))
+//		})
+//	}
+func ContextWithBuilder(ctx context.Context, b *Builder) context.Context {
+	if parent, ok := ctx.Value(builderCtxKey{}).(*Builder); ok {
+		b = parent.With(b.options...)
+	}
+	return context.WithValue(ctx, builderCtxKey{}, b)
+}
+
+// BuilderFromContext returns the Builder carried by ctx. If ctx carries no Builder, it returns an empty Builder.
+func BuilderFromContext(ctx context.Context) *Builder {
+	if ctx != nil {
+		if b, ok := ctx.Value(builderCtxKey{}).(*Builder); ok {
+			return b
+		}
+	}
+	return NewBuilder()
+}
+
+// ContextExtractor returns options from ctx, such as a trace ID set by a tracing library. It is called by NewCtx and WrapCtx. It must be safe for concurrent use and should return nil if ctx has nothing to extract.
+type ContextExtractor func(ctx context.Context) []Option
+
+var contextExtractors struct {
+	mu         sync.RWMutex
+	extractors []ContextExtractor
+}
+
+// RegisterContextExtractor registers an extractor applied to all errors created by NewCtx and WrapCtx. It is usually called in init function.
+//
+// Usage:
+//
+//	goerr.RegisterContextExtractor(func(ctx context.Context) []goerr.Option {
+//		if sc := trace.SpanContextFromContext(ctx); sc.HasTraceID() {
+//			return []goerr.Option{goerr.V("trace_id", sc.TraceID().String())}
+//		}
+//		return nil
+//	})
+func RegisterContextExtractor(extractor ContextExtractor) {
+	contextExtractors.mu.Lock()
+	defer contextExtractors.mu.Unlock()
+	contextExtractors.extractors = append(contextExtractors.extractors, extractor)
+}
+
+// contextOptions returns options of registered extractors, options of the Builder carried by ctx and options in this order. Later options override earlier ones.
+func contextOptions(ctx context.Context, options []Option) []Option {
+	if ctx == nil {
+		return options
+	}
+
+	var merged []Option
+	contextExtractors.mu.RLock()
+	for _, extractor := range contextExtractors.extractors {
+		merged = append(merged, extractor(ctx)...)
+	}
+	contextExtractors.mu.RUnlock()
+
+	return append(merged, BuilderFromContext(ctx).mergeOptions(options)...)
+}
+
+// NewCtx creates a new error with message and options carried by ctx. Options of registered ContextExtractor and the Builder set by ContextWithBuilder are applied before options.
+func NewCtx(ctx context.Context, msg string, options ...Option) *Error {
+	err := newError(contextOptions(ctx, options)...)
+	err.msg = msg
+	return err
+}
+
+// WrapCtx creates a new Error with caused error, message and options carried by ctx. Options of registered ContextExtractor and the Builder set by ContextWithBuilder are applied before options.
+//
+// Usage:
+//
+//	if err := repo.Save(ctx, user); err != nil {
+//		return goerr.WrapCtx(ctx, err, "failed to save user", goerr.V("user_id", user.ID))
+//	}
+func WrapCtx(ctx context.Context, cause error, msg string, options ...Option) *Error {
+	err := newError(contextOptions(ctx, options)...)
+	err.msg = msg
+	err.setCause(ctx, cause)
+	return err
+}
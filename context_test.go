@@ -0,0 +1,94 @@
+package goerr_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/m-mizutani/goerr/v2"
+)
+
+type traceIDCtxKey struct{}
+
+func init() {
+	goerr.RegisterContextExtractor(func(ctx context.Context) []goerr.Option {
+		if id, ok := ctx.Value(traceIDCtxKey{}).(string); ok {
+			return []goerr.Option{goerr.V("trace_id", id), goerr.V("source", "extractor")}
+		}
+		return nil
+	})
+}
+
+func TestNewCtx(t *testing.T) {
+	ctx := goerr.ContextWithBuilder(context.Background(), goerr.NewBuilder(goerr.V("request_id", "req1"), goerr.V("source", "builder")))
+	ctx = context.WithValue(ctx, traceIDCtxKey{}, "trace1")
+
+	err := goerr.NewCtx(ctx, "failed", goerr.V("user_id", "u1"))
+	values := err.Values()
+	if values["request_id"] != "req1" || values["trace_id"] != "trace1" || values["user_id"] != "u1" {
+		t.Errorf("Unexpected values: %v", values)
+	}
+	// Builder options override extractor options
+	if values["source"] != "builder" {
+		t.Errorf("Expected builder to override extractor, got %v", values["source"])
+	}
+	if err.Error() != "failed" {
+		t.Errorf("Unexpected message: %s", err.Error())
+	}
+	if !strings.HasSuffix(err.Stacks()[0].Func, ".TestNewCtx") {
+		t.Errorf("Stack trace should start at caller: %s", err.Stacks()[0].Func)
+	}
+
+	// Explicit options override options in context
+	err = goerr.NewCtx(ctx, "failed", goerr.V("request_id", "req2"))
+	if err.Values()["request_id"] != "req2" {
+		t.Errorf("Expected explicit option to override, got %v", err.Values()["request_id"])
+	}
+}
+
+func TestWrapCtx(t *testing.T) {
+	cause := errors.New("no rows")
+	ctx := goerr.ContextWithBuilder(context.Background(), goerr.NewBuilder(goerr.V("tenant", "t1")))
+
+	err := goerr.WrapCtx(ctx, cause, "failed to get user")
+	if !errors.Is(err, cause) || err.Error() != "failed to get user: no rows" {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if err.Values()["tenant"] != "t1" {
+		t.Errorf("Unexpected values: %v", err.Values())
+	}
+	if _, ok := err.Values()["trace_id"]; ok {
+		t.Error("Extractor should not add values if context has nothing to extract")
+	}
+	if !strings.HasSuffix(err.Stacks()[0].Func, ".TestWrapCtx") {
+		t.Errorf("Stack trace should start at caller: %s", err.Stacks()[0].Func)
+	}
+}
+
+func TestContextWithBuilderNested(t *testing.T) {
+	ctx := goerr.ContextWithBuilder(context.Background(), goerr.NewBuilder(goerr.V("a", 1), goerr.V("b", 1)))
+	child := goerr.ContextWithBuilder(ctx, goerr.NewBuilder(goerr.V("b", 2), goerr.V("c", 2)))
+
+	values := goerr.NewCtx(child, "failed").Values()
+	if values["a"] != 1 || values["b"] != 2 || values["c"] != 2 {
+		t.Errorf("Unexpected values: %v", values)
+	}
+
+	// Parent context is not changed
+	if _, ok := goerr.NewCtx(ctx, "failed").Values()["c"]; ok {
+		t.Error("Parent context should not be changed")
+	}
+}
+
+func TestBuilderFromContext(t *testing.T) {
+	if b := goerr.BuilderFromContext(context.Background()); b == nil || len(b.New("x").Values()) != 0 {
+		t.Error("Expected empty builder")
+	}
+
+	// nil context is treated as a context without options
+	err := goerr.NewCtx(nil, "failed", goerr.V("k", "v"))
+	if err.Values()["k"] != "v" {
+		t.Errorf("Unexpected values: %v", err.Values())
+	}
+}
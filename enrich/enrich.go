@@ -0,0 +1,85 @@
+// Package enrich provides enrichers of standard library errors for goerr. They are not registered by goerr itself because values extracted from causes change Values() and log output of existing code, and importing net, net/url and os/exec links the network stack and cgo into every binary. Call RegisterStdlib to enable them.
+//
+// Usage:
+//
+//	func main() {
+//		enrich.RegisterStdlib()
+//		// ...
+//	}
+package enrich
+
+import (
+	"encoding/json"
+	"net"
+	"net/url"
+	"os"
+	"os/exec"
+	"sync"
+
+	"github.com/m-mizutani/goerr/v2"
+)
+
+// stderrTailSize is maximum number of bytes of stderr extracted from *exec.ExitError.
+const stderrTailSize = 512
+
+var registerStdlib sync.Once
+
+// RegisterStdlib registers enrichers of *os.PathError, *os.LinkError, *os.SyscallError, *net.OpError, *net.DNSError, *url.Error, *json.SyntaxError, *json.UnmarshalTypeError, *exec.ExitError and *exec.Error. Keys of their values are prefixed by the package name, such as "os.path" and "exec.exit_code", not to conflict with keys of applications. Password in URL is redacted, and stderr of *exec.ExitError is truncated to the last 512 bytes. It is safe to call RegisterStdlib more than once.
+func RegisterStdlib() {
+	registerStdlib.Do(func() {
+		for _, enricher := range stdlibEnrichers() {
+			goerr.RegisterEnricher(enricher)
+		}
+	})
+}
+
+func stdlibEnrichers() []goerr.Enricher {
+	return []goerr.Enricher{
+		goerr.EnricherFor(func(err *os.PathError) []goerr.Option {
+			return []goerr.Option{goerr.V("os.op", err.Op), goerr.V("os.path", err.Path)}
+		}),
+		goerr.EnricherFor(func(err *os.LinkError) []goerr.Option {
+			return []goerr.Option{goerr.V("os.op", err.Op), goerr.V("os.old", err.Old), goerr.V("os.new", err.New)}
+		}),
+		goerr.EnricherFor(func(err *os.SyscallError) []goerr.Option {
+			return []goerr.Option{goerr.V("os.syscall", err.Syscall)}
+		}),
+		goerr.EnricherFor(func(err *net.OpError) []goerr.Option {
+			options := []goerr.Option{goerr.V("net.op", err.Op), goerr.V("net.network", err.Net)}
+			if err.Addr != nil {
+				options = append(options, goerr.V("net.addr", err.Addr.String()))
+			}
+			return options
+		}),
+		goerr.EnricherFor(func(err *net.DNSError) []goerr.Option {
+			return []goerr.Option{goerr.V("dns.name", err.Name), goerr.V("dns.server", err.Server)}
+		}),
+		goerr.EnricherFor(func(err *url.Error) []goerr.Option {
+			// Password in URL must not be exposed in logs
+			u := err.URL
+			if parsed, parseErr := url.Parse(err.URL); parseErr == nil {
+				u = parsed.Redacted()
+			}
+			return []goerr.Option{goerr.V("url.method", err.Op), goerr.V("url.url", u)}
+		}),
+		goerr.EnricherFor(func(err *json.SyntaxError) []goerr.Option {
+			return []goerr.Option{goerr.V("json.offset", err.Offset)}
+		}),
+		goerr.EnricherFor(func(err *json.UnmarshalTypeError) []goerr.Option {
+			return []goerr.Option{goerr.V("json.offset", err.Offset), goerr.V("json.field", err.Field), goerr.V("json.value", err.Value)}
+		}),
+		goerr.EnricherFor(func(err *exec.ExitError) []goerr.Option {
+			options := []goerr.Option{goerr.V("exec.exit_code", err.ExitCode())}
+			if stderr := err.Stderr; len(stderr) > 0 {
+				if len(stderr) > stderrTailSize {
+					stderr = stderr[len(stderr)-stderrTailSize:]
+				}
+				options = append(options, goerr.V("exec.stderr", string(stderr)))
+			}
+			return options
+		}),
+		goerr.EnricherFor(func(err *exec.Error) []goerr.Option {
+			return []goerr.Option{goerr.V("exec.name", err.Name)}
+		}),
+	}
+}
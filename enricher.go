@@ -0,0 +1,84 @@
+package goerr
+
+import (
+	"sync"
+)
+
+// Enricher extracts options, usually values, from a cause. Enrichers are called by Wrap, With and other functions wrapping a cause, for each error in the cause chain until *Error. It must be safe for concurrent use and should return nil for unsupported errors.
+type Enricher func(cause error) []Option
+
+// EnricherFor creates an Enricher for errors of type T. f is called only if the cause is T.
+//
+// Usage:
+//
+//	goerr.RegisterEnricher(goerr.EnricherFor(func(err *pq.Error) []goerr.Option {
+//		return []goerr.Option{goerr.V("pq_code", string(err.Code))}
+//	}))
+func EnricherFor[T error](f func(T) []Option) Enricher {
+	return func(cause error) []Option {
+		if e, ok := cause.(T); ok {
+			return f(e)
+		}
+		return nil
+	}
+}
+
+var enrichers struct {
+	mu   sync.RWMutex
+	list []Enricher
+}
+
+// RegisterEnricher registers an enricher for errors of your own types. No enricher is registered by default; enrichers of standard library errors such as *os.PathError and *exec.ExitError are registered by RegisterStdlib of package github.com/m-mizutani/goerr/v2/enrich. It is usually called in init function.
+//
+// Enrichers registered later override values of earlier ones for the same cause. Values extracted from causes never override values set by options, and values of an outer cause take precedence over an inner cause.
+func RegisterEnricher(enricher Enricher) {
+	enrichers.mu.Lock()
+	defer enrichers.mu.Unlock()
+	enrichers.list = append(enrichers.list, enricher)
+}
+
+// extract returns an *Error that has values, typed values and tags extracted from err by all enrichers. It does not look into wrapped errors of err.
+func extract(err error) *Error {
+	enrichers.mu.RLock()
+	list := enrichers.list
+	enrichers.mu.RUnlock()
+
+	extracted := &Error{values: make(values), typedValues: make(map[string]any), tags: make(tags)}
+	for _, enricher := range list {
+		for _, opt := range enricher(err) {
+			opt(extracted)
+		}
+	}
+	return extracted
+}
+
+// enrich applies enrichers to the cause chain of x until *Error.
+func (x *Error) enrich() {
+	w := newWalker()
+	for err := x.cause; err != nil && w.enter(err); {
+		if _, ok := err.(*Error); ok {
+			return
+		}
+
+		extracted := extract(err)
+		for k, v := range extracted.values {
+			if _, exists := x.values[k]; !exists {
+				x.values[k] = v
+			}
+		}
+		for k, v := range extracted.typedValues {
+			if _, exists := x.typedValues[k]; !exists {
+				x.typedValues[k] = v
+			}
+		}
+		for t := range extracted.tags {
+			x.tags[t] = struct{}{}
+		}
+
+		u, ok := err.(interface{ Unwrap() error })
+		if !ok {
+			return
+		}
+		err = u.Unwrap()
+	}
+}
@@ -0,0 +1,85 @@
+package goerr_test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/m-mizutani/goerr/v2"
+)
+
+type quotaError struct {
+	limit int
+}
+
+func (x *quotaError) Error() string { return fmt.Sprintf("quota exceeded: %d", x.limit) }
+
+var tagQuota = goerr.NewTag("quota")
+
+func init() {
+	goerr.RegisterEnricher(goerr.EnricherFor(func(err *quotaError) []goerr.Option {
+		return []goerr.Option{goerr.V("limit", err.limit), goerr.T(tagQuota)}
+	}))
+}
+
+func TestEnricher(t *testing.T) {
+	testCases := map[string]struct {
+		cause error
+		want  map[string]any
+	}{
+		"custom error": {
+			cause: &quotaError{limit: 10},
+			want:  map[string]any{"limit": 10},
+		},
+		"wrapped by fmt.Errorf": {
+			cause: fmt.Errorf("failed to check: %w", &quotaError{limit: 10}),
+			want:  map[string]any{"limit": 10},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			for _, err := range []*goerr.Error{goerr.Wrap(tc.cause, "wrapped"), goerr.With(tc.cause)} {
+				values := err.Values()
+				for k, v := range tc.want {
+					if values[k] != v {
+						t.Errorf("Expected %s=%v, got %v", k, v, values)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestEnricherNotRegisteredByDefault(t *testing.T) {
+	_, pathErr := os.Open("/path/to/not/exist")
+	err := goerr.Wrap(pathErr, "failed")
+	if len(err.Values()) != 0 {
+		t.Errorf("Expected no values of standard library error, got %v", err.Values())
+	}
+	if fields := err.Printable().Cause.(*goerr.PrintableCause).Fields; fields != nil {
+		t.Errorf("Expected no fields, got %v", fields)
+	}
+}
+
+func TestEnricherPrecedence(t *testing.T) {
+	// Explicit options are not overridden
+	err := goerr.Wrap(&quotaError{limit: 1}, "failed", goerr.V("limit", "explicit"))
+	if err.Values()["limit"] != "explicit" {
+		t.Errorf("Unexpected limit: %v", err.Values()["limit"])
+	}
+
+	// Tags set by custom enricher
+	if !goerr.Wrap(&quotaError{limit: 1}, "failed").HasTag(tagQuota) {
+		t.Error("Expected tag by enricher")
+	}
+
+	// Values of inner error are merged, and PrintableCause has fields of custom enricher
+	outer := goerr.Wrap(goerr.Wrap(&quotaError{limit: 1}, "inner"), "outer")
+	if outer.Values()["limit"] != 1 {
+		t.Error("Merged values should contain limit of inner error")
+	}
+	if outer.Printable().Cause.(*goerr.Printable).Cause.(*goerr.PrintableCause).Fields["limit"] != 1 {
+		t.Error("Expected fields of custom enricher in PrintableCause")
+	}
+}
@@ -0,0 +1,226 @@
+package goerr
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// CompareOption is an option for Equal and Diff.
+type CompareOption func(*compareConfig)
+
+type compareConfig struct {
+	ignoreValues  bool
+	compareStacks bool
+	ignoreKeys    map[string]struct{}
+}
+
+// IgnoreValues makes Equal and Diff ignore values and typed values.
+func IgnoreValues() CompareOption {
+	return func(cfg *compareConfig) {
+		cfg.ignoreValues = true
+	}
+}
+
+// CompareStacks makes Equal and Diff compare function names of stack traces. Stack traces are ignored by default because errors created by the same code differ in stack traces when called from different functions.
+func CompareStacks() CompareOption {
+	return func(cfg *compareConfig) {
+		cfg.compareStacks = true
+	}
+}
+
+// IgnoreKeys makes Equal and Diff ignore values and typed values of the keys. It is useful for values that differ on every call, such as timestamps or request IDs.
+func IgnoreKeys(keys ...string) CompareOption {
+	return func(cfg *compareConfig) {
+		for _, key := range keys {
+			cfg.ignoreKeys[key] = struct{}{}
+		}
+	}
+}
+
+// Equal returns true if a and b are structurally equal. See Diff for compared fields.
+//
+// Usage:
+//
+//	if goerr.Equal(got, want, goerr.IgnoreKeys("timestamp")) {
+//		// same error except timestamp
+//	}
+func Equal(a, b error, options ...CompareOption) bool {
+	return Diff(a, b, options...) == ""
+}
+
+// Diff returns human readable differences between a and b, one difference per line. It returns an empty string if a and b are structurally equal.
+//
+// Errors are compared level by level from outermost *Error to innermost one. Each level is compared by message, ID, operation, severity, expected mark, tags, values, typed values, and function names of stack trace if CompareStacks is given, without merging values of wrapped errors. The last cause that is not *Error is compared by type and message. Other errors between *Error levels, such as fmt.Errorf with %w, are not compared. Values are compared by reflect.DeepEqual.
+//
+// Each line is prefixed by the level, e.g.
+//
+//	[0].message: "failed to handle" != "failed to process"
+//	[1].values["user_id"]: "u1" != "u2"
+func Diff(a, b error, options ...CompareOption) string {
+	cfg := &compareConfig{ignoreKeys: make(map[string]struct{})}
+	for _, opt := range options {
+		opt(cfg)
+	}
+
+	if a == nil || b == nil {
+		if a == nil && b == nil {
+			return ""
+		}
+		return fmt.Sprintf("error: %s != %s\n", describeError(a), describeError(b))
+	}
+
+	la, lb := compareLevels(a), compareLevels(b)
+
+	var d differ
+	for i := 0; i < len(la) || i < len(lb); i++ {
+		prefix := fmt.Sprintf("[%d]", i)
+		switch {
+		case i >= len(la):
+			d.add(prefix, "<missing>", describeError(lb[i]))
+		case i >= len(lb):
+			d.add(prefix, describeError(la[i]), "<missing>")
+		default:
+			cfg.diffLevel(&d, prefix, la[i], lb[i])
+		}
+	}
+
+	return d.String()
+}
+
+// compareLevels returns *Error in err from outermost to innermost, and the last cause that is not *Error.
+func compareLevels(err error) []error {
+	e := Unwrap(err)
+	if e == nil {
+		return []error{err}
+	}
+
+	chain := e.chain()
+	levels := make([]error, 0, len(chain)+1)
+	for _, c := range chain {
+		levels = append(levels, c)
+	}
+	if last := chain[len(chain)-1]; last.cause != nil && Unwrap(last.cause) == nil {
+		levels = append(levels, last.cause)
+	}
+	return levels
+}
+
+func (cfg *compareConfig) diffLevel(d *differ, prefix string, a, b error) {
+	ea, okA := a.(*Error)
+	eb, okB := b.(*Error)
+	if !okA || !okB {
+		if typeName(a) != typeName(b) {
+			d.add(prefix+".type", typeName(a), typeName(b))
+		}
+		if a.Error() != b.Error() {
+			d.add(prefix+".message", fmt.Sprintf("%q", a.Error()), fmt.Sprintf("%q", b.Error()))
+		}
+		return
+	}
+
+	if ea.msg != eb.msg {
+		d.add(prefix+".message", fmt.Sprintf("%q", ea.msg), fmt.Sprintf("%q", eb.msg))
+	}
+	if ea.id != eb.id {
+		d.add(prefix+".id", fmt.Sprintf("%q", ea.id), fmt.Sprintf("%q", eb.id))
+	}
+	if ea.op != eb.op {
+		d.add(prefix+".op", fmt.Sprintf("%q", ea.op), fmt.Sprintf("%q", eb.op))
+	}
+	if ea.severity != eb.severity {
+		d.add(prefix+".severity", fmt.Sprintf("%q", ea.severity), fmt.Sprintf("%q", eb.severity))
+	}
+	if ea.expected != eb.expected {
+		d.add(prefix+".expected", fmt.Sprint(ea.expected), fmt.Sprint(eb.expected))
+	}
+	// Tags are stored in a map, so they must be sorted before comparison
+	ta, tb := ea.tags.list(), eb.tags.list()
+	sort.Strings(ta)
+	sort.Strings(tb)
+	if !reflect.DeepEqual(ta, tb) {
+		d.add(prefix+".tags", fmt.Sprint(ta), fmt.Sprint(tb))
+	}
+
+	if !cfg.ignoreValues {
+		cfg.diffValues(d, prefix+".values", ea.values, eb.values)
+		cfg.diffValues(d, prefix+".typed_values", ea.typedValues, eb.typedValues)
+	}
+
+	if cfg.compareStacks {
+		if fa, fb := stackFuncs(ea), stackFuncs(eb); !reflect.DeepEqual(fa, fb) {
+			d.add(prefix+".stacktrace", fmt.Sprint(fa), fmt.Sprint(fb))
+		}
+	}
+}
+
+func (cfg *compareConfig) diffValues(d *differ, prefix string, a, b map[string]any) {
+	keys := make([]string, 0, len(a)+len(b))
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		if _, ok := cfg.ignoreKeys[k]; ok {
+			continue
+		}
+
+		va, okA := a[k]
+		vb, okB := b[k]
+		if okA && okB && reflect.DeepEqual(va, vb) {
+			continue
+		}
+
+		sa, sb := "<missing>", "<missing>"
+		if okA {
+			sa = fmt.Sprintf("%#v", va)
+		}
+		if okB {
+			sb = fmt.Sprintf("%#v", vb)
+		}
+		d.add(fmt.Sprintf("%s[%q]", prefix, k), sa, sb)
+	}
+}
+
+// stackFuncs returns function names of stack trace. File paths and line numbers are not compared because they change with unrelated edits.
+func stackFuncs(x *Error) []string {
+	stacks := x.Stacks()
+	if len(stacks) == 0 {
+		return nil
+	}
+
+	funcs := make([]string, len(stacks))
+	for i, st := range stacks {
+		funcs[i] = st.Func
+	}
+	return funcs
+}
+
+func describeError(err error) string {
+	if err == nil {
+		return "<nil>"
+	}
+	if e, ok := err.(*Error); ok {
+		return fmt.Sprintf("%q", e.msg)
+	}
+	return fmt.Sprintf("%q (%s)", err.Error(), typeName(err))
+}
+
+type differ struct {
+	b strings.Builder
+}
+
+func (x *differ) add(path, a, b string) {
+	fmt.Fprintf(&x.b, "%s: %s != %s\n", path, a, b)
+}
+
+func (x *differ) String() string {
+	return x.b.String()
+}
@@ -0,0 +1,163 @@
+package goerr_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/m-mizutani/goerr/v2"
+)
+
+var (
+	tagEqual       = goerr.NewTag("equal")
+	equalUserIDKey = goerr.NewTypedKey[string]("user_id")
+)
+
+type equalError struct{ msg string }
+
+func (x *equalError) Error() string { return x.msg }
+
+func newEqualError(id string, ts time.Time) error {
+	cause := goerr.New("not found", goerr.ID("not_found"), goerr.T(tagEqual), goerr.V("id", id))
+	return goerr.Wrap(cause, "failed to get", goerr.V("ts", ts))
+}
+
+func newEqualErrorFromOtherFunc(id string, ts time.Time) error {
+	return newEqualError(id, ts)
+}
+
+func TestEqual(t *testing.T) {
+	ts := time.Now()
+
+	testCases := map[string]struct {
+		a, b    error
+		options []goerr.CompareOption
+		want    bool
+	}{
+		"same": {
+			a:    newEqualError("u1", ts),
+			b:    newEqualError("u1", ts),
+			want: true,
+		},
+		"different value": {
+			a:    newEqualError("u1", ts),
+			b:    newEqualError("u2", ts),
+			want: false,
+		},
+		"ignore values": {
+			a:       newEqualError("u1", ts),
+			b:       newEqualError("u2", ts.Add(time.Second)),
+			options: []goerr.CompareOption{goerr.IgnoreValues()},
+			want:    true,
+		},
+		"ignore keys": {
+			a:       newEqualError("u1", ts),
+			b:       newEqualError("u1", ts.Add(time.Second)),
+			options: []goerr.CompareOption{goerr.IgnoreKeys("ts")},
+			want:    true,
+		},
+		"ignore other keys": {
+			a:       newEqualError("u1", ts),
+			b:       newEqualError("u2", ts.Add(time.Second)),
+			options: []goerr.CompareOption{goerr.IgnoreKeys("ts")},
+			want:    false,
+		},
+		"different stack": {
+			a:    newEqualError("u1", ts),
+			b:    newEqualErrorFromOtherFunc("u1", ts),
+			want: true,
+		},
+		"compare stacks": {
+			a:       newEqualError("u1", ts),
+			b:       newEqualErrorFromOtherFunc("u1", ts),
+			options: []goerr.CompareOption{goerr.CompareStacks()},
+			want:    false,
+		},
+		"compare same stacks": {
+			a:       newEqualError("u1", ts),
+			b:       newEqualError("u1", ts),
+			options: []goerr.CompareOption{goerr.CompareStacks()},
+			want:    true,
+		},
+		"different depth": {
+			a:    goerr.Wrap(goerr.New("x"), "y"),
+			b:    goerr.New("y"),
+			want: false,
+		},
+		"standard cause": {
+			a:    goerr.Wrap(errors.New("x"), "y"),
+			b:    goerr.Wrap(errors.New("x"), "y"),
+			want: true,
+		},
+		"standard cause by fmt.Errorf": {
+			a:    goerr.Wrap(errors.New("x"), "y"),
+			b:    goerr.Wrap(fmt.Errorf("x"), "y"),
+			want: true,
+		},
+		"different standard cause type": {
+			a:    goerr.Wrap(errors.New("x"), "y"),
+			b:    goerr.Wrap(&equalError{msg: "x"}, "y"),
+			want: false,
+		},
+		"standard errors": {
+			a:    errors.New("x"),
+			b:    errors.New("x"),
+			want: true,
+		},
+		"nil": {
+			a:    nil,
+			b:    nil,
+			want: true,
+		},
+		"nil and error": {
+			a:    nil,
+			b:    errors.New("x"),
+			want: false,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if got := goerr.Equal(tc.a, tc.b, tc.options...); got != tc.want {
+				t.Errorf("Expected %v, got %v\n%s", tc.want, got, goerr.Diff(tc.a, tc.b, tc.options...))
+			}
+		})
+	}
+}
+
+func TestEqualMultipleTags(t *testing.T) {
+	newTagged := func() error {
+		return goerr.New("failed",
+			goerr.T(goerr.NewTag("a")), goerr.T(goerr.NewTag("b")),
+			goerr.T(goerr.NewTag("c")), goerr.T(goerr.NewTag("d")))
+	}
+
+	// Map iteration order differs between calls, so repeat to catch order dependency
+	for i := 0; i < 100; i++ {
+		if diff := goerr.Diff(newTagged(), newTagged()); diff != "" {
+			t.Fatalf("Expected no diff, got %s", diff)
+		}
+	}
+}
+
+func TestDiff(t *testing.T) {
+	a := goerr.Wrap(goerr.New("not found", goerr.ID("a"), goerr.V("id", "u1")), "failed", goerr.T(tagEqual))
+	b := goerr.Wrap(goerr.New("not found", goerr.ID("b"), goerr.TV(equalUserIDKey, "u2")), "failure")
+
+	want := `[0].message: "failed" != "failure"
+[0].tags: [equal] != []
+[1].id: "a" != "b"
+[1].values["id"]: "u1" != <missing>
+[1].typed_values["user_id"]: <missing> != "u2"
+`
+	if got := goerr.Diff(a, b); got != want {
+		t.Errorf("Unexpected diff:\n%s", got)
+	}
+
+	want = `[1]: "not found" (*errors.errorString) != <missing>
+`
+	if got := goerr.Diff(goerr.Wrap(errors.New("not found"), "x"), goerr.New("x")); got != want {
+		t.Errorf("Unexpected diff:\n%s", got)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"sort"
+	"sync/atomic"
 
 	"log/slog"
 )
@@ -45,6 +46,19 @@ func T(t tag) Option {
 	return Tag(t)
 }
 
+// Unstack trims stack trace by n. It can be used for internal helper or utility functions. Unlike (*Error).Unstack, it never modifies an existing error.
+//
+// Usage:
+//
+//	func newDomainError(msg string) *goerr.Error {
+//		return goerr.New(msg, goerr.Unstack(1)) // stack trace starts from the caller of newDomainError
+//	}
+func Unstack(n int) Option {
+	return func(err *Error) {
+		err.st = unstack(err.st, n)
+	}
+}
+
 // New creates a new error with message
 func New(msg string, options ...Option) *Error {
 	err := newError(options...)
@@ -52,7 +66,7 @@ func New(msg string, options ...Option) *Error {
 	return err
 }
 
-// Wrap creates a new Error and add message.
+// Wrap creates a new Error and add message. If cause is a third-party error that has its own stack trace, such as an error of github.com/pkg/errors, the stack trace of the cause is used as the origin instead of capturing a new one.
 //
 // Usage:
 //   baseErr := fmt.Errorf("connection failed")
@@ -62,22 +76,18 @@ func New(msg string, options ...Option) *Error {
 func Wrap(cause error, msg string, options ...Option) *Error {
 	err := newError(options...)
 	err.msg = msg
-	err.cause = cause
+	err.setCause(nil, cause)
 
 	return err
 }
 
-// Unwrap returns unwrapped goerr.Error from err by errors.As. If no goerr.Error, returns nil
+// Unwrap returns unwrapped goerr.Error from err in the same order as errors.As. If no goerr.Error, returns nil. Cyclic Unwrap and chains deeper than MaxChainDepth are safely cut off.
 // NOTE: Do not receive error interface. It causes typed-nil problem.
 //
 //	var err error = goerr.New("error")
 //	if err != nil { // always true
 func Unwrap(err error) *Error {
-	var e *Error
-	if errors.As(err, &e) {
-		return e
-	}
-	return nil
+	return newWalker().find(err)
 }
 
 // Values returns map of key and value that is set by With. All wrapped goerr.Error key and values will be merged. Key and values of wrapped error is overwritten by upper goerr.Error.
@@ -132,6 +142,12 @@ type Error struct {
 	values      values         // String-based values
 	typedValues map[string]any // Type-safe values
 	tags        tags
+	op          string
+	msgFormat   *MessageFormat // nil means the global format set by SetMessageFormat
+	severity    SeverityLevel
+	expected    bool
+	logged      *atomic.Bool // Set by MarkLogged. Shared only by copies created by With. It is nil for sentinel errors
+	sentinel    bool // Created by Sentinel. It has no stack trace and must not be modified
 }
 
 func newError(options ...Option) *Error {
@@ -141,6 +157,7 @@ func newError(options ...Option) *Error {
 		typedValues: make(map[string]any),
 		id:          "", // Default to empty string. Empty string is treated as invalid ID
 		tags:        make(tags),
+		logged:      new(atomic.Bool),
 	}
 
 	for _, opt := range options {
@@ -154,6 +171,10 @@ func (x *Error) copy(dst *Error, options ...Option) {
 	dst.msg = x.msg
 	dst.id = x.id
 	dst.cause = x.cause
+	dst.op = x.op
+	dst.msgFormat = x.msgFormat
+	dst.severity = x.severity
+	dst.expected = x.expected
 
 	dst.tags = x.tags.clone()
 	dst.values = x.values.clone()
@@ -172,65 +193,121 @@ func (x *Error) copy(dst *Error, options ...Option) {
 
 // Printable returns printable object
 func (x *Error) Printable() *Printable {
-	e := &Printable{
-		Message:     x.msg,
-		ID:          x.id,
-		StackTrace:  x.Stacks(),
-		Values:      x.Values(),      // Use Values() to get merged string-based values from wrapped errors
-		TypedValues: x.TypedValues(), // Use TypedValues() to get merged typed values from wrapped errors
-		Tags:        x.Tags(),        // Use Tags() to get merged tags from wrapped errors
+	chain := x.chain()
+	omitStack := x.omitStack()
+
+	// Build from innermost error to merge values and tags level by level without recursion
+	mergedValues := make(values)
+	mergedTypedValues := make(map[string]any)
+	mergedTags := make(tags)
+	severity := SeverityUnspecified
+	expected := false
+	var ops []string
+	printables := make([]*Printable, len(chain))
+	for i := len(chain) - 1; i >= 0; i-- {
+		e := chain[i]
+		for k, v := range e.values {
+			mergedValues[k] = v
+		}
+		for k, v := range e.typedValues {
+			mergedTypedValues[k] = v
+		}
+		for t := range e.tags {
+			mergedTags[t] = struct{}{}
+		}
+		severity = max(severity, e.severity)
+		expected = expected || e.expected
+		if e.op != "" {
+			ops = append([]string{e.op}, ops...)
+		}
+
+		printables[i] = &Printable{
+			Message:     e.msg,
+			ID:          e.id,
+			Type:        typeName(e),
+			Severity:    severity.String(),
+			Expected:    expected,
+			Ops:         ops,
+			Values:      mergedValues.clone(),
+			TypedValues: values(mergedTypedValues).clone(),
+			Tags:        mergedTags.list(),
+		}
+		switch {
+		case omitStack:
+			// Stack traces of expected errors are omitted
+		case DeferredSymbolization():
+			printables[i].RawStack = e.RawStack()
+		default:
+			printables[i].StackTrace = e.Stacks()
+		}
 	}
 
-	if cause := Unwrap(x.cause); cause != nil {
-		e.Cause = cause.Printable()
-	} else if x.cause != nil {
-		e.Cause = x.cause.Error()
+	for i, p := range printables {
+		if i+1 < len(printables) {
+			p.Cause = printables[i+1]
+		} else if cause := chain[i].cause; cause != nil {
+			p.Cause = newPrintableCause(cause)
+		}
 	}
-	return e
+
+	return printables[0]
 }
 
+// Printable is printable object of *goerr.Error. Cause is *Printable if the cause is *goerr.Error, otherwise *PrintableCause. If deferred symbolization is enabled by SetDeferredSymbolization, RawStack is set instead of StackTrace. Neither is set if the error is expected, see Expected.
 type Printable struct {
 	Message     string         `json:"message"`
 	ID          string         `json:"id"`
+	Type        string         `json:"type"`
+	Severity    string         `json:"severity,omitempty"`
+	Expected    bool           `json:"expected,omitempty"`
+	Ops         []string       `json:"ops,omitempty"`
 	StackTrace  []*Stack       `json:"stacktrace"`
+	RawStack    *RawStack      `json:"raw_stack,omitempty"`
 	Cause       any            `json:"cause"`
 	Values      map[string]any `json:"values"`
 	TypedValues map[string]any `json:"typed_values"`
 	Tags        []string       `json:"tags"`
 }
 
-// Error returns error message for error interface
+// Error returns error message for error interface. Messages of the chain are composed by the format set by MsgFormat option or SetMessageFormat, "msg: cause" by default.
 func (x *Error) Error() string {
-	if x.cause == nil {
-		return x.msg
+	f := x.msgFormat
+	if f == nil {
+		global := CurrentMessageFormat()
+		f = &global
 	}
-
-	if x.msg == "" {
-		return x.cause.Error()
-	}
-
-	return fmt.Sprintf("%s: %v", x.msg, x.cause.Error())
+	return f.compose(x)
 }
 
 // Format returns:
 // - %v, %s, %q: formatted message
-// - %+v: formatted message with stack trace
+// - %+v: formatted message with stack trace. Stack trace is omitted if the error is expected, see Expected
 func (x *Error) Format(s fmt.State, verb rune) {
 	switch verb {
 	case 'v':
 		if s.Flag('+') {
 			_, _ = io.WriteString(s, x.Error())
-			var c *Error
-			for c = x; c.Unwrap() != nil; {
+			// Print the innermost stacktrace. Sentinel errors have no stacktrace
+			var st *stack
+			for c := x; c != nil; {
+				if c.st != nil {
+					st = c.st
+				}
 				cause, ok := c.Unwrap().(*Error)
 				if !ok {
 					break
 				}
 				c = cause
 			}
-			c.st.Format(s, verb)
+			if st != nil && !x.omitStack() {
+				st.Format(s, verb)
+			}
 			_, _ = io.WriteString(s, "\n")
 
+			if ops := x.opsPath(); ops != "" {
+				_, _ = io.WriteString(s, "\nOps:\n  "+ops+"\n\n")
+			}
+
 			// Use merged values from entire error chain
 			mergedValues := x.Values()
 			if len(mergedValues) > 0 {
@@ -272,19 +349,38 @@ func (x *Error) Format(s fmt.State, verb rune) {
 	}
 }
 
+// ErrorOrNil returns the error if x is not nil, nil otherwise. Use it to return *Error through an error interface without the typed-nil problem.
+// Nil-safe: (*goerr.Error)(nil).ErrorOrNil() returns nil
+func (x *Error) ErrorOrNil() error {
+	if x == nil {
+		return nil
+	}
+	return x
+}
+
 // Unwrap returns *fundamental of github.com/pkg/errors
 func (x *Error) Unwrap() error {
 	return x.cause
 }
 
 // Unstack trims stack trace by 1. It can be used for internal helper or utility functions.
+//
+// Deprecated: Unstack modifies the error in place and is not safe for an error shared between goroutines. Use the goerr.Unstack option with goerr.New(), goerr.Wrap() or goerr.With() instead.
 func (x *Error) Unstack() *Error {
+	if x.sentinel {
+		x = x.clone()
+	}
 	x.st = unstack(x.st, 1)
 	return x
 }
 
 // UnstackN trims stack trace by n. It can be used for internal helper or utility functions.
+//
+// Deprecated: UnstackN modifies the error in place and is not safe for an error shared between goroutines. Use the goerr.Unstack option with goerr.New(), goerr.Wrap() or goerr.With() instead.
 func (x *Error) UnstackN(n int) *Error {
+	if x.sentinel {
+		x = x.clone()
+	}
 	x.st = unstack(x.st, n)
 	return x
 }
@@ -308,7 +404,7 @@ func (x *Error) Is(target error) bool {
 func (x *Error) Wrap(cause error, options ...Option) *Error {
 	err := newError()
 	x.copy(err, options...)
-	err.cause = cause
+	err.setCause(nil, cause)
 	return err
 }
 
@@ -325,66 +421,45 @@ func (x *Error) TypedValues() map[string]any {
 func (x *Error) mergedValues() values {
 	merged := make(values)
 
-	if cause := x.Unwrap(); cause != nil {
-		if err := Unwrap(cause); err != nil {
-			merged = err.mergedValues()
+	// Merge string-based values from innermost error
+	chain := x.chain()
+	for i := len(chain) - 1; i >= 0; i-- {
+		for key, value := range chain[i].values {
+			merged[key] = value
 		}
 	}
 
-	// Merge string-based values
-	for key, value := range x.values {
-		merged[key] = value
-	}
-
 	return merged
 }
 
 func (x *Error) mergedTypedValues() map[string]any {
 	merged := make(map[string]any)
 
-	if cause := x.Unwrap(); cause != nil {
-		if err := Unwrap(cause); err != nil {
-			merged = err.mergedTypedValues()
+	// Merge typed values from innermost error
+	chain := x.chain()
+	for i := len(chain) - 1; i >= 0; i-- {
+		for key, value := range chain[i].typedValues {
+			merged[key] = value
 		}
 	}
 
-	// Merge typed values
-	for key, value := range x.typedValues {
-		merged[key] = value
-	}
-
 	return merged
 }
 
 // Tags returns list of tags that is set by WithTags. All wrapped goerr.Error tags will be merged. Tags of wrapped error is overwritten by upper goerr.Error.
 func (x *Error) Tags() []string {
-	tags := x.mergedTags()
-
-	for tag := range x.tags {
-		tags[tag] = struct{}{}
-	}
-
-	tagList := make([]string, 0, len(tags))
-	for tag := range tags {
-		tagList = append(tagList, tag.value)
-	}
-
-	return tagList
+	return x.mergedTags().list()
 }
 
 func (x *Error) mergedTags() tags {
 	merged := make(tags)
 
-	if cause := x.Unwrap(); cause != nil {
-		if err := Unwrap(cause); err != nil {
-			merged = err.mergedTags()
+	for _, e := range x.chain() {
+		for tag := range e.tags {
+			merged[tag] = struct{}{}
 		}
 	}
 
-	for tag := range x.tags {
-		merged[tag] = struct{}{}
-	}
-
 	return merged
 }
 
@@ -399,9 +474,87 @@ func (x *Error) LogValue() slog.Value {
 	if x == nil {
 		return slog.AnyValue(nil)
 	}
+	return x.logValue()
+}
+
+// logValue returns slog.Value of x. It builds values of x and wrapped *Error from innermost to outermost in one pass over the chain without recursion, as Printable does. Severity, expected mark and operations of each level are accumulated from inner levels, and stack traces of all levels are omitted if the outermost error is expected.
+func (x *Error) logValue() slog.Value {
+	chain := x.chain()
+	if len(chain) == 0 {
+		chain = []*Error{x}
+	}
+
+	// Operations of a level are a suffix of all operations, so they share one slice
+	var allOps []string
+	opStarts := make([]int, len(chain))
+	for i, e := range chain {
+		opStarts[i] = len(allOps)
+		if e.op != "" {
+			allOps = append(allOps, e.op)
+		}
+	}
+
+	severities := make([]SeverityLevel, len(chain))
+	expected := make([]bool, len(chain))
+	severity := SeverityUnspecified
+	isExpected := false
+	for i := len(chain) - 1; i >= 0; i-- {
+		severity = max(severity, chain[i].severity)
+		isExpected = isExpected || chain[i].expected
+		severities[i], expected[i] = severity, isExpected
+	}
+	omitStack := !ExpectedStacks() && expected[0]
+
+	// Only *Error directly wrapped by *Error is nested as "cause" group. Other causes, including *Error wrapped by another error type, are logged by their own LogValue or by causeLogValue
+	depth := 1
+	for depth < len(chain) && chain[depth-1].cause == error(chain[depth]) {
+		depth++
+	}
+
+	var cause slog.Value
+	for i := depth - 1; i >= 0; i-- {
+		e := chain[i]
+		attrs := e.logAttrs(severities[i], expected[i], allOps[opStarts[i]:], omitStack)
+		switch {
+		case i+1 < depth:
+			attrs = append(attrs, slog.Any("cause", cause))
+		case e.cause == nil:
+		case isErrorType(e.cause):
+			// *Error not in chain is cut off by MaxChainDepth or cyclic
+			attrs = append(attrs, slog.Any("cause", causeLogValue(e.cause)))
+		default:
+			if lv, ok := e.cause.(slog.LogValuer); ok {
+				attrs = append(attrs, slog.Any("cause", lv.LogValue()))
+			} else {
+				attrs = append(attrs, slog.Any("cause", causeLogValue(e.cause)))
+			}
+		}
+		cause = slog.GroupValue(attrs...)
+	}
+
+	return cause
+}
+
+// isErrorType returns true if err is *Error.
+func isErrorType(err error) bool {
+	_, ok := err.(*Error)
+	return ok
+}
 
+// logAttrs returns attributes of x itself for LogValue. severity, expected and ops are accumulated values of x and its wrapped errors.
+func (x *Error) logAttrs(severity SeverityLevel, expected bool, ops []string, omitStack bool) []slog.Attr {
 	attrs := []slog.Attr{
 		slog.String("message", x.msg),
+		slog.String("type", typeName(x)),
+	}
+	if severity != SeverityUnspecified {
+		attrs = append(attrs, slog.String("severity", severity.String()))
+	}
+	if expected {
+		attrs = append(attrs, slog.Bool("expected", true))
+	}
+	if len(ops) > 0 {
+		attrs = append(attrs, slog.Any("ops", ops))
 	}
 
 	var values []any
@@ -423,25 +576,24 @@ func (x *Error) LogValue() slog.Value {
 	attrs = append(attrs, slog.Any("tags", tags))
 
 	var stacktrace any
-	var traces []string
-	for _, st := range x.StackTrace() {
-		traces = append(traces, fmt.Sprintf("%s:%d %s", st.getFilePath(), st.getLineNumber(), st.getFunctionName()))
-	}
-	stacktrace = traces
-
-	attrs = append(attrs, slog.Any("stacktrace", stacktrace))
-
-	if x.cause != nil {
-		var errAttr slog.Attr
-		if lv, ok := x.cause.(slog.LogValuer); ok {
-			errAttr = slog.Any("cause", lv.LogValue())
-		} else {
-			errAttr = slog.Any("cause", x.cause)
+	switch {
+	case omitStack:
+		// Stack traces of expected errors are omitted
+	case DeferredSymbolization():
+		if raw := x.RawStack(); raw != nil {
+			attrs = append(attrs, slog.Any("raw_stack", raw))
+		}
+	default:
+		var traces []string
+		if x.st != nil {
+			for _, st := range x.st.frames() {
+				traces = append(traces, fmt.Sprintf("%s:%d %s", st.File, st.Line, st.Func))
+			}
 		}
-		attrs = append(attrs, errAttr)
+		stacktrace = traces
 	}
 
-	return slog.GroupValue(attrs...)
+	return append(attrs, slog.Any("stacktrace", stacktrace))
 }
 
 // MarshalJSON implements json.Marshaler interface for Error type.
@@ -454,10 +606,10 @@ func (x *Error) MarshalJSON() ([]byte, error) {
 	return json.Marshal(x.Printable())
 }
 
-// With adds contextual information to an error without modifying the original. It is useful when you want to enrich an error with more context in a middleware or a higher-level function without altering the original error value.
+// With adds contextual information to an error without modifying the original. It is safe to call With for an error shared between goroutines, such as a package-level sentinel error. It is useful when you want to enrich an error with more context in a middleware or a higher-level function without altering the original error value.
 //
-// If err is a *goerr.Error, it creates a new *Error that preserves the original stacktrace and adds the new options.
-// If err is a standard error, it wraps the error in a new *goerr.Error with a new stacktrace and adds the options.
+// If err is a *goerr.Error, it creates a new *Error that preserves the original stacktrace and adds the new options. If err is a sentinel error created by Sentinel, the new *Error has stacktrace of the call site instead.
+// If err is a standard error, it wraps the error in a new *goerr.Error with a new stacktrace and adds the options. If err has its own stack trace, such as an error of github.com/pkg/errors, the stack trace is used instead.
 //
 // Usage:
 //   originalErr := goerr.New("validation failed")
@@ -471,14 +623,17 @@ func With(err error, options ...Option) *Error {
 	if goErr, ok := err.(*Error); ok {
 		// For goerr.Error, create new error preserving stacktrace
 		newErr := newError()
+		if !goErr.sentinel {
+			newErr.st = goErr.st // Preserve original stacktrace. It must be set before options to be trimmed by Unstack option
+			newErr.logged = goErr.logged // A copy by With is the same failure, so it shares the logged mark
+		}
 		goErr.copy(newErr, options...)
-		newErr.st = goErr.st // Preserve original stacktrace
 		return newErr
 	}
 
 	// For non-goerr.Error, wrap with new stacktrace
 	newErr := newError(options...)
-	newErr.cause = err
+	newErr.setCause(nil, err)
 	// Leave msg empty so Error() returns only cause.Error()
 	return newErr
 }
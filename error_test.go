@@ -1,10 +1,12 @@
 package goerr_test
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"log/slog"
+	"os"
 	"reflect"
 	"strings"
 	"testing"
@@ -373,6 +375,44 @@ func TestErrorUnstack(t *testing.T) {
 	}
 }
 
+func newHelperError(msg string) *goerr.Error {
+	return goerr.New(msg, goerr.Unstack(1))
+}
+
+func TestUnstackOption(t *testing.T) {
+	err := newHelperError("test error")
+	stacks := err.Stacks()
+	if len(stacks) == 0 {
+		t.Fatal("Expected stack trace")
+	}
+	if !strings.HasSuffix(stacks[0].Func, "TestUnstackOption") {
+		t.Errorf("Expected stack to start from caller of helper, got %s", stacks[0].Func)
+	}
+
+	// With and Unstack option does not modify the original error
+	original := goerr.New("original")
+	origLen := len(original.Stacks())
+	trimmed := goerr.With(original, goerr.Unstack(1))
+	if len(original.Stacks()) != origLen {
+		t.Error("Original error should not be modified")
+	}
+	if len(trimmed.Stacks()) != origLen-1 {
+		t.Errorf("Expected %d frames, got %d", origLen-1, len(trimmed.Stacks()))
+	}
+}
+
+func TestErrorOrNil(t *testing.T) {
+	var nilErr *goerr.Error
+	if nilErr.ErrorOrNil() != nil {
+		t.Error("ErrorOrNil of nil *Error should return untyped nil")
+	}
+
+	err := goerr.New("test")
+	if err.ErrorOrNil() != error(err) {
+		t.Error("ErrorOrNil should return the error itself")
+	}
+}
+
 func TestPrintable(t *testing.T) {
 	tag := goerr.NewTag("test")
 	cause := fmt.Errorf("cause error")
@@ -393,15 +433,86 @@ func TestPrintable(t *testing.T) {
 		t.Error("Printable should have tags")
 	}
 
-	if printable.Cause != "cause error" {
+	if cause, ok := printable.Cause.(*goerr.PrintableCause); !ok || cause.Message != "cause error" {
 		t.Error("Printable should have cause")
 	}
 
+	if printable.Type != "*goerr.Error" {
+		t.Errorf("Printable should have type, got %q", printable.Type)
+	}
+
 	if len(printable.StackTrace) == 0 {
 		t.Error("Printable should have stack trace")
 	}
 }
 
+func TestPrintableCauseType(t *testing.T) {
+	_, openErr := os.Open("/path/to/not/exist")
+	err := goerr.Wrap(openErr, "failed to open")
+
+	cause, ok := err.Printable().Cause.(*goerr.PrintableCause)
+	if !ok {
+		t.Fatalf("Expected *goerr.PrintableCause, got %T", err.Printable().Cause)
+	}
+	if cause.Type != "*fs.PathError" {
+		t.Errorf("Expected type '*fs.PathError', got %q", cause.Type)
+	}
+
+	// Fields are extracted by registered enricher
+	cause = goerr.Wrap(&quotaError{limit: 12}, "failed to call").Printable().Cause.(*goerr.PrintableCause)
+	if cause.Type != "*goerr_test.quotaError" || cause.Fields["limit"] != 12 {
+		t.Errorf("Unexpected cause: %+v", cause)
+	}
+
+	// Unknown error type has type name but no fields
+	cause = goerr.Wrap(errors.New("plain"), "wrapped").Printable().Cause.(*goerr.PrintableCause)
+	if cause.Type != "*errors.errorString" || cause.Fields != nil {
+		t.Errorf("Unexpected cause: %+v", cause)
+	}
+
+	// JSON output has type for each level
+	data, jsonErr := json.Marshal(goerr.Wrap(goerr.Wrap(openErr, "inner"), "outer"))
+	if jsonErr != nil {
+		t.Fatal(jsonErr)
+	}
+	var decoded map[string]any
+	if jsonErr := json.Unmarshal(data, &decoded); jsonErr != nil {
+		t.Fatal(jsonErr)
+	}
+	inner := decoded["cause"].(map[string]any)
+	root := inner["cause"].(map[string]any)
+	if decoded["type"] != "*goerr.Error" || inner["type"] != "*goerr.Error" || root["type"] != "*fs.PathError" {
+		t.Errorf("Unexpected JSON: %s", string(data))
+	}
+}
+
+func TestLogValueCauseType(t *testing.T) {
+	err := goerr.Wrap(&quotaError{limit: 12}, "failed to call")
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	logger.Error("test", slog.Any("error", err))
+
+	var out struct {
+		Error struct {
+			Type  string `json:"type"`
+			Cause struct {
+				Type   string         `json:"type"`
+				Fields map[string]any `json:"fields"`
+			} `json:"cause"`
+		} `json:"error"`
+	}
+	if jsonErr := json.Unmarshal(buf.Bytes(), &out); jsonErr != nil {
+		t.Fatal(jsonErr)
+	}
+	if out.Error.Type != "*goerr.Error" {
+		t.Errorf("Unexpected type: %s", out.Error.Type)
+	}
+	if out.Error.Cause.Type != "*goerr_test.quotaError" || out.Error.Cause.Fields["limit"] != float64(12) {
+		t.Errorf("Unexpected cause: %s", buf.String())
+	}
+}
+
 func TestWith_WithGoError(t *testing.T) {
 	// Create original goerr.Error
 	original := goerr.New("original message", goerr.Value("orig_key", "orig_value"))
@@ -7,29 +7,34 @@ import (
 	"io"
 	"log/slog"
 	"strconv"
-	"strings"
 )
 
 // Errors represents multiple errors as a single error
 type Errors struct {
 	errs []error
+
+	maxErrors int
+	overflow  int
+	dedupKey  func(error) string
+	index     map[string]int // dedup key to index of errs
+	counts    []int          // occurrences of each error in errs. It is set only if dedupKey is set
+
+	// ErrorFormat formats messages of Error and %+v. If nil, the global format set by SetErrorFormat is used, and DefaultErrorFormat if neither is set.
+	ErrorFormat ErrorFormatFunc
 }
 
-// Error implements error interface
+// Error implements error interface. Each *Error is formatted by its message format, see SetMessageFormat.
 func (x *Errors) Error() string {
 	if x == nil || len(x.errs) == 0 {
 		return ""
 	}
 
-	if len(x.errs) == 1 {
-		return x.errs[0].Error()
+	errs := x.countedErrors()
+	f := x.errorFormat()
+	if f == nil {
+		f = DefaultErrorFormat
 	}
-
-	var messages []string
-	for _, err := range x.errs {
-		messages = append(messages, err.Error())
-	}
-	return strings.Join(messages, "\n")
+	return withOverflow(f(errs), x.overflow)
 }
 
 // Unwrap returns all wrapped errors for Go 1.20+ multiple errors support
@@ -91,7 +96,7 @@ func Join(errs ...error) *Errors {
 }
 
 // Append adds errors to existing Errors (inspired by go-multierror)
-// If base is nil, creates a new Errors. Flattens nested Errors.
+// If base is nil, creates a new Errors. Flattens nested Errors. Limits and de-duplication set by NewErrors options of base are applied.
 func Append(base *Errors, errs ...error) *Errors {
 	if len(errs) == 0 {
 		return base
@@ -111,23 +116,22 @@ func Append(base *Errors, errs ...error) *Errors {
 
 		// Flatten nested Errors (go-multierror pattern)
 		if nestedErrs, ok := err.(*Errors); ok {
-			base.errs = append(base.errs, nestedErrs.errs...)
+			for i, nested := range nestedErrs.errs {
+				base.add(nested, nestedErrs.count(i))
+			}
+			base.overflow += nestedErrs.overflow
 		} else {
-			base.errs = append(base.errs, err)
+			base.add(err, 1)
 		}
 	}
 
 	return base
 }
 
-// AsErrors extracts goerr.Errors from err by errors.As. If no goerr.Errors, returns nil
-// Complementary to goerr.Unwrap() which only extracts goerr.Error
+// AsErrors extracts goerr.Errors from err in the same order as errors.As. If no goerr.Errors, returns nil
+// Complementary to goerr.Unwrap() which only extracts goerr.Error. Like Unwrap, it stops at cyclic Unwrap and MaxChainDepth
 func AsErrors(err error) *Errors {
-	var e *Errors
-	if errors.As(err, &e) {
-		return e
-	}
-	return nil
+	return newWalker().findErrors(err)
 }
 
 // IsEmpty returns true if no errors are contained
@@ -179,9 +183,11 @@ func (x *Errors) HasTag(tag tag) bool {
 	return false
 }
 
-// ErrorsJSON represents JSON structure for Errors
+// ErrorsJSON represents JSON structure for Errors. Overflow is the number of errors not stored because of MaxErrors. Counts is occurrences of each error if DedupErrors is set.
 type ErrorsJSON struct {
-	Errors []any `json:"errors"`
+	Errors   []any `json:"errors"`
+	Overflow int   `json:"overflow,omitempty"`
+	Counts   []int `json:"counts,omitempty"`
 }
 
 // MarshalJSON implements json.Marshaler interface for Errors type
@@ -191,7 +197,11 @@ func (x *Errors) MarshalJSON() ([]byte, error) {
 	}
 
 	result := ErrorsJSON{
-		Errors: make([]any, len(x.errs)),
+		Errors:   make([]any, len(x.errs)),
+		Overflow: x.overflow,
+	}
+	if x.counts != nil {
+		result.Counts = x.Counts()
 	}
 
 	// Serialize each error
@@ -235,10 +245,17 @@ func (x *Errors) LogValue() slog.Value {
 	}
 	attrs = append(attrs, slog.Group("errors", errorAttrs...))
 
+	if x.counts != nil {
+		attrs = append(attrs, slog.Any("counts", x.Counts()))
+	}
+	if x.overflow > 0 {
+		attrs = append(attrs, slog.Int("overflow", x.overflow))
+	}
+
 	return slog.GroupValue(attrs...)
 }
 
-// Format implements fmt.Formatter interface
+// Format implements fmt.Formatter interface. %+v prints details of each error by %+v, laid out by ErrorFormat or the global format if set.
 func (x *Errors) Format(s fmt.State, verb rune) {
 	if x == nil {
 		return
@@ -247,10 +264,22 @@ func (x *Errors) Format(s fmt.State, verb rune) {
 	switch verb {
 	case 'v':
 		if s.Flag('+') {
-			// Detailed format with all error details
+			// Detailed format with all error details by the configured format
+			if f := x.errorFormat(); f != nil {
+				detailed := make([]error, len(x.errs))
+				for i, err := range x.errs {
+					detailed[i] = &detailedError{err: err, count: x.count(i)}
+				}
+				_, _ = io.WriteString(s, withOverflow(f(detailed), x.overflow)+"\n")
+				return
+			}
+
 			fmt.Fprintf(s, "Errors (%d):\n", len(x.errs))
 			for i, err := range x.errs {
-				fmt.Fprintf(s, "  [%d] %+v\n", i, err)
+				fmt.Fprintf(s, "  [%d] %s\n", i, withCount(fmt.Sprintf("%+v", err), x.count(i)))
+			}
+			if x.overflow > 0 {
+				_, _ = io.WriteString(s, "  "+overflowMessage(x.overflow)+"\n")
 			}
 			return
 		}
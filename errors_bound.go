@@ -0,0 +1,184 @@
+package goerr
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ErrorsOption is an option for NewErrors.
+type ErrorsOption func(*Errors)
+
+// MaxErrors limits the number of errors stored in *Errors to n. Excess errors are not stored but counted, and the count is reported by Overflow, Error, MarshalJSON and LogValue. n <= 0 means no limit.
+func MaxErrors(n int) ErrorsOption {
+	return func(errs *Errors) {
+		errs.maxErrors = n
+	}
+}
+
+// DedupErrors makes *Errors keep only the first error of the same key as a representative and count the others. The count is reported by Counts, Error, MarshalJSON and LogValue. If key is nil, ID of *Error is used as the key, or type and message if the error has no ID.
+func DedupErrors(key func(err error) string) ErrorsOption {
+	if key == nil {
+		key = dedupKey
+	}
+	return func(errs *Errors) {
+		errs.dedupKey = key
+		errs.index = make(map[string]int)
+	}
+}
+
+// NewErrors creates an empty *Errors with options. Use Append to add errors.
+//
+// Usage:
+//
+//	errs := goerr.NewErrors(goerr.MaxErrors(100), goerr.DedupErrors(nil))
+//	for _, record := range records {
+//		if err := validate(record); err != nil {
+//			errs = goerr.Append(errs, err)
+//		}
+//	}
+//	return errs.ErrorOrNil()
+func NewErrors(options ...ErrorsOption) *Errors {
+	errs := &Errors{errs: make([]error, 0)}
+	for _, opt := range options {
+		opt(errs)
+	}
+	return errs
+}
+
+// dedupKey returns the outermost ID of err, or type and message if err has no ID.
+func dedupKey(err error) string {
+	if e := Unwrap(err); e != nil {
+		for _, c := range e.chain() {
+			if c.id != "" {
+				return "id:" + c.id
+			}
+		}
+	}
+	return typeName(err) + ":" + err.Error()
+}
+
+// add stores err that occurred n times, or counts it as a duplicate or an overflow.
+func (x *Errors) add(err error, n int) {
+	var key string
+	if x.dedupKey != nil {
+		key = x.dedupKey(err)
+		if i, ok := x.index[key]; ok {
+			x.counts[i] += n
+			return
+		}
+	}
+
+	if x.maxErrors > 0 && len(x.errs) >= x.maxErrors {
+		x.overflow += n
+		return
+	}
+
+	x.errs = append(x.errs, err)
+	if x.dedupKey != nil {
+		x.index[key] = len(x.errs) - 1
+		x.counts = append(x.counts, n)
+	}
+}
+
+// count returns occurrences of the i-th error.
+func (x *Errors) count(i int) int {
+	if x.counts == nil {
+		return 1
+	}
+	return x.counts[i]
+}
+
+// Overflow returns the number of errors not stored because of MaxErrors.
+func (x *Errors) Overflow() int {
+	if x == nil {
+		return 0
+	}
+	return x.overflow
+}
+
+// Counts returns occurrences of each error in the same order as Errors. All counts are 1 unless DedupErrors is set.
+func (x *Errors) Counts() []int {
+	if x == nil || len(x.errs) == 0 {
+		return nil
+	}
+
+	counts := make([]int, len(x.errs))
+	for i := range x.errs {
+		counts[i] = x.count(i)
+	}
+	return counts
+}
+
+// Total returns the number of all added errors including duplicates and overflow.
+func (x *Errors) Total() int {
+	if x == nil {
+		return 0
+	}
+
+	total := x.overflow
+	for i := range x.errs {
+		total += x.count(i)
+	}
+	return total
+}
+
+// countedError is a representative of duplicated errors. Its message has the number of occurrences.
+type countedError struct {
+	err   error
+	count int
+}
+
+func (x *countedError) Error() string {
+	return withCount(x.err.Error(), x.count)
+}
+
+func (x *countedError) Unwrap() error {
+	return x.err
+}
+
+// countedErrors returns errors of x with the number of occurrences for formatting messages.
+func (x *Errors) countedErrors() []error {
+	if x.counts == nil {
+		return x.errs
+	}
+
+	errs := make([]error, len(x.errs))
+	for i, err := range x.errs {
+		if n := x.count(i); n > 1 {
+			errs[i] = &countedError{err: err, count: n}
+		} else {
+			errs[i] = err
+		}
+	}
+	return errs
+}
+
+// withCount appends the number of occurrences to the first line of msg, e.g. "invalid name (x3)".
+func withCount(msg string, n int) string {
+	if n <= 1 {
+		return msg
+	}
+	suffix := fmt.Sprintf(" (x%d)", n)
+	if first, rest, found := strings.Cut(msg, "\n"); found {
+		return first + suffix + "\n" + rest
+	}
+	return msg + suffix
+}
+
+// withOverflow appends the number of errors not stored to msg. It is appended as a new line to a multi-line message and with "; " to a single line message.
+func withOverflow(msg string, n int) string {
+	if n <= 0 {
+		return msg
+	}
+	if strings.Contains(msg, "\n") {
+		return msg + "\n" + overflowMessage(n)
+	}
+	return msg + "; " + overflowMessage(n)
+}
+
+func overflowMessage(n int) string {
+	if n == 1 {
+		return "and 1 more error omitted"
+	}
+	return fmt.Sprintf("and %d more errors omitted", n)
+}
@@ -0,0 +1,149 @@
+package goerr_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/m-mizutani/goerr/v2"
+)
+
+func TestMaxErrors(t *testing.T) {
+	errs := goerr.NewErrors(goerr.MaxErrors(2))
+	for i := 0; i < 5; i++ {
+		errs = goerr.Append(errs, fmt.Errorf("invalid record %d", i))
+	}
+
+	if errs.Len() != 2 {
+		t.Errorf("Expected 2 stored errors, got %d", errs.Len())
+	}
+	if errs.Overflow() != 3 {
+		t.Errorf("Expected overflow 3, got %d", errs.Overflow())
+	}
+	if errs.Total() != 5 {
+		t.Errorf("Expected total 5, got %d", errs.Total())
+	}
+
+	if got, want := errs.Error(), "invalid record 0\ninvalid record 1\nand 3 more errors omitted"; got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+
+	errs.ErrorFormat = goerr.SingleLineFormat(0)
+	if got, want := errs.Error(), "invalid record 0; invalid record 1; and 3 more errors omitted"; got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+
+	data, err := json.Marshal(errs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded goerr.ErrorsJSON
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if len(decoded.Errors) != 2 || decoded.Overflow != 3 || decoded.Counts != nil {
+		t.Errorf("Unexpected JSON: %s", data)
+	}
+
+	var buf bytes.Buffer
+	slog.New(slog.NewJSONHandler(&buf, nil)).Error("failed", slog.Any("errors", errs))
+	if !strings.Contains(buf.String(), `"overflow":3`) {
+		t.Errorf("Expected overflow in log, got %s", buf.String())
+	}
+}
+
+func TestMaxErrorsNested(t *testing.T) {
+	nested := goerr.NewErrors(goerr.MaxErrors(1))
+	nested = goerr.Append(nested, errors.New("a"), errors.New("b"))
+
+	errs := goerr.NewErrors(goerr.MaxErrors(2))
+	errs = goerr.Append(errs, errors.New("c"), nested, errors.New("d"))
+
+	if got, want := errs.Error(), "c\na\nand 2 more errors omitted"; got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+	if errs.Total() != 4 {
+		t.Errorf("Expected total 4, got %d", errs.Total())
+	}
+}
+
+func TestDedupErrors(t *testing.T) {
+	errs := goerr.NewErrors(goerr.DedupErrors(nil))
+	for i := 0; i < 3; i++ {
+		errs = goerr.Append(errs, goerr.New(fmt.Sprintf("row %d is invalid", i), goerr.ID("invalid_row")))
+	}
+	errs = goerr.Append(errs, errors.New("timeout"), errors.New("timeout"))
+	errs = goerr.Append(errs, errors.New("canceled"))
+
+	if errs.Len() != 3 {
+		t.Errorf("Expected 3 representatives, got %d", errs.Len())
+	}
+	if want := []int{3, 2, 1}; !reflect.DeepEqual(errs.Counts(), want) {
+		t.Errorf("Expected counts %v, got %v", want, errs.Counts())
+	}
+	if errs.Total() != 6 {
+		t.Errorf("Expected total 6, got %d", errs.Total())
+	}
+
+	if got, want := errs.Error(), "row 0 is invalid (x3)\ntimeout (x2)\ncanceled"; got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+	if !errors.Is(errs, errs.Errors()[1]) {
+		t.Error("Expected representative to be matched by errors.Is")
+	}
+
+	data, err := json.Marshal(errs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded goerr.ErrorsJSON
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if want := []int{3, 2, 1}; !reflect.DeepEqual(decoded.Counts, want) {
+		t.Errorf("Expected counts %v in JSON, got %s", want, data)
+	}
+
+	out := fmt.Sprintf("%+v", errs)
+	if !strings.Contains(out, "[0] row 0 is invalid (x3)\n") {
+		t.Errorf("Expected count in detailed output, got %s", out)
+	}
+}
+
+func TestDedupErrorsWithMax(t *testing.T) {
+	errs := goerr.NewErrors(
+		goerr.MaxErrors(1),
+		goerr.DedupErrors(func(err error) string { return strings.Fields(err.Error())[0] }),
+	)
+	errs = goerr.Append(errs,
+		errors.New("invalid name"),
+		errors.New("missing email"),
+		errors.New("invalid age"),
+	)
+
+	// Duplicates of stored errors are counted even if the limit is reached
+	if want := []int{2}; !reflect.DeepEqual(errs.Counts(), want) {
+		t.Errorf("Expected counts %v, got %v", want, errs.Counts())
+	}
+	if errs.Overflow() != 1 {
+		t.Errorf("Expected overflow 1, got %d", errs.Overflow())
+	}
+	if got, want := errs.Error(), "invalid name (x2); and 1 more error omitted"; got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
+
+func TestNewErrorsEmpty(t *testing.T) {
+	errs := goerr.NewErrors(goerr.MaxErrors(10))
+	if errs.ErrorOrNil() != nil {
+		t.Error("Expected nil for empty errors")
+	}
+	if errs.Counts() != nil || errs.Total() != 0 {
+		t.Error("Expected no counts for empty errors")
+	}
+}
@@ -0,0 +1,136 @@
+package goerr
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+)
+
+// ErrorFormatFunc formats errors of *Errors into a message. It is compatible with ErrorFormatFunc of github.com/hashicorp/go-multierror.
+type ErrorFormatFunc func(errs []error) string
+
+var errorFormat atomic.Pointer[ErrorFormatFunc]
+
+// SetErrorFormat sets the global format of *Errors without ErrorFormat. nil restores DefaultErrorFormat.
+//
+// Usage:
+//
+//	goerr.SetErrorFormat(goerr.SingleLineFormat(10))
+func SetErrorFormat(f ErrorFormatFunc) {
+	if f == nil {
+		errorFormat.Store(nil)
+		return
+	}
+	errorFormat.Store(&f)
+}
+
+// CurrentErrorFormat returns the global format set by SetErrorFormat, or nil if not set.
+func CurrentErrorFormat() ErrorFormatFunc {
+	if f := errorFormat.Load(); f != nil {
+		return *f
+	}
+	return nil
+}
+
+// errorFormat returns the format of x, the global format or nil in this order.
+func (x *Errors) errorFormat() ErrorFormatFunc {
+	if x.ErrorFormat != nil {
+		return x.ErrorFormat
+	}
+	return CurrentErrorFormat()
+}
+
+// DefaultErrorFormat joins messages of errs with newline. It returns the message as is for a single error.
+func DefaultErrorFormat(errs []error) string {
+	if len(errs) == 1 {
+		return errs[0].Error()
+	}
+
+	messages := make([]string, len(errs))
+	for i, err := range errs {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "\n")
+}
+
+// BulletFormat returns a format of bulleted list in the same way as go-multierror. If limit is positive, at most limit errors are printed followed by "and N more".
+//
+//	3 errors occurred:
+//		* invalid name
+//		* invalid email
+//		* and 1 more
+func BulletFormat(limit int) ErrorFormatFunc {
+	return func(errs []error) string {
+		lines, rest := formatMessages(errs, limit, func(_ int, msg string) string {
+			return "\t* " + msg
+		})
+		if rest > 0 {
+			lines = append(lines, fmt.Sprintf("\t* and %d more", rest))
+		}
+		return fmt.Sprintf("%s occurred:\n%s", countErrors(len(errs)), strings.Join(lines, "\n"))
+	}
+}
+
+// SingleLineFormat returns a format joining messages with "; ". If limit is positive, at most limit errors are printed followed by "and N more".
+//
+//	invalid name; invalid email; and 1 more
+func SingleLineFormat(limit int) ErrorFormatFunc {
+	return func(errs []error) string {
+		messages, rest := formatMessages(errs, limit, func(_ int, msg string) string {
+			return msg
+		})
+		if rest > 0 {
+			messages = append(messages, fmt.Sprintf("and %d more", rest))
+		}
+		return strings.Join(messages, "; ")
+	}
+}
+
+// NumberedFormat returns a format of numbered list with the count of errors. If limit is positive, at most limit errors are printed followed by "and N more".
+//
+//	3 errors:
+//	  1. invalid name
+//	  2. invalid email
+//	  and 1 more
+func NumberedFormat(limit int) ErrorFormatFunc {
+	return func(errs []error) string {
+		lines, rest := formatMessages(errs, limit, func(i int, msg string) string {
+			return fmt.Sprintf("  %d. %s", i+1, msg)
+		})
+		if rest > 0 {
+			lines = append(lines, fmt.Sprintf("  and %d more", rest))
+		}
+		return fmt.Sprintf("%s:\n%s", countErrors(len(errs)), strings.Join(lines, "\n"))
+	}
+}
+
+// formatMessages formats messages of at most limit errors by format, and returns the number of errors not formatted.
+func formatMessages(errs []error, limit int, format func(i int, msg string) string) ([]string, int) {
+	n := len(errs)
+	if limit > 0 && n > limit {
+		n = limit
+	}
+
+	messages := make([]string, n)
+	for i, err := range errs[:n] {
+		messages[i] = format(i, err.Error())
+	}
+	return messages, len(errs) - n
+}
+
+func countErrors(n int) string {
+	if n == 1 {
+		return "1 error"
+	}
+	return fmt.Sprintf("%d errors", n)
+}
+
+// detailedError is an error whose message is the detailed format of err by %+v with the number of occurrences. It is used to apply ErrorFormatFunc to %+v of *Errors.
+type detailedError struct {
+	err   error
+	count int
+}
+
+func (x *detailedError) Error() string {
+	return withCount(strings.TrimRight(fmt.Sprintf("%+v", x.err), "\n"), x.count)
+}
@@ -0,0 +1,115 @@
+package goerr_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/m-mizutani/goerr/v2"
+)
+
+func newValidationErrors() *goerr.Errors {
+	return goerr.Join(
+		errors.New("invalid name"),
+		errors.New("invalid email"),
+		errors.New("invalid age"),
+	)
+}
+
+func TestErrorFormatFunc(t *testing.T) {
+	testCases := map[string]struct {
+		format goerr.ErrorFormatFunc
+		want   string
+	}{
+		"default": {
+			format: goerr.DefaultErrorFormat,
+			want:   "invalid name\ninvalid email\ninvalid age",
+		},
+		"bullet": {
+			format: goerr.BulletFormat(0),
+			want:   "3 errors occurred:\n\t* invalid name\n\t* invalid email\n\t* invalid age",
+		},
+		"bullet with limit": {
+			format: goerr.BulletFormat(2),
+			want:   "3 errors occurred:\n\t* invalid name\n\t* invalid email\n\t* and 1 more",
+		},
+		"single line": {
+			format: goerr.SingleLineFormat(0),
+			want:   "invalid name; invalid email; invalid age",
+		},
+		"single line with limit": {
+			format: goerr.SingleLineFormat(1),
+			want:   "invalid name; and 2 more",
+		},
+		"numbered": {
+			format: goerr.NumberedFormat(0),
+			want:   "3 errors:\n  1. invalid name\n  2. invalid email\n  3. invalid age",
+		},
+		"numbered with limit": {
+			format: goerr.NumberedFormat(2),
+			want:   "3 errors:\n  1. invalid name\n  2. invalid email\n  and 1 more",
+		},
+		"limit larger than errors": {
+			format: goerr.SingleLineFormat(10),
+			want:   "invalid name; invalid email; invalid age",
+		},
+		"custom": {
+			format: func(errs []error) string { return fmt.Sprintf("%d problems", len(errs)) },
+			want:   "3 problems",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			errs := newValidationErrors()
+			errs.ErrorFormat = tc.format
+			if got := errs.Error(); got != tc.want {
+				t.Errorf("Expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestSetErrorFormat(t *testing.T) {
+	goerr.SetErrorFormat(goerr.SingleLineFormat(0))
+	t.Cleanup(func() { goerr.SetErrorFormat(nil) })
+
+	errs := newValidationErrors()
+	if got, want := errs.Error(), "invalid name; invalid email; invalid age"; got != want {
+		t.Errorf("Expected global format %q, got %q", want, got)
+	}
+
+	errs.ErrorFormat = goerr.BulletFormat(1)
+	if got, want := errs.Error(), "3 errors occurred:\n\t* invalid name\n\t* and 2 more"; got != want {
+		t.Errorf("Expected instance format %q, got %q", want, got)
+	}
+
+	goerr.SetErrorFormat(nil)
+	if goerr.CurrentErrorFormat() != nil {
+		t.Error("Expected global format to be reset")
+	}
+	if got, want := newValidationErrors().Error(), "invalid name\ninvalid email\ninvalid age"; got != want {
+		t.Errorf("Expected default format %q, got %q", want, got)
+	}
+}
+
+func TestErrorFormatDetailed(t *testing.T) {
+	errs := goerr.Join(goerr.New("invalid name", goerr.V("field", "name")), errors.New("invalid email"))
+
+	if out := fmt.Sprintf("%+v", errs); !strings.HasPrefix(out, "Errors (2):\n") {
+		t.Errorf("Expected default detailed header, got %s", out)
+	}
+
+	errs.ErrorFormat = goerr.NumberedFormat(0)
+	out := fmt.Sprintf("%+v", errs)
+	if !strings.HasPrefix(out, "2 errors:\n  1. invalid name\n") {
+		t.Errorf("Expected numbered detailed output, got %s", out)
+	}
+	if !strings.Contains(out, "field: name") || !strings.Contains(out, "errors_format_test.go") {
+		t.Errorf("Expected details of errors, got %s", out)
+	}
+	if !strings.Contains(out, "  2. invalid email") {
+		t.Errorf("Expected second error, got %s", out)
+	}
+}
@@ -0,0 +1,70 @@
+// Code generated by goerr-gen. DO NOT EDIT.
+
+package main
+
+import (
+	"time"
+
+	"github.com/m-mizutani/goerr/v2"
+)
+
+var (
+	// TagNotFound is error tag "not_found". Requested resource does not exist
+	TagNotFound = goerr.NewTag("not_found")
+	// TagRetryable is error tag "retryable". Operation can be retried
+	TagRetryable = goerr.NewTag("retryable")
+)
+
+var (
+	// UserIDKey is typed key "user_id".
+	UserIDKey = goerr.NewTypedKey[string]("user_id")
+	// TimeoutKey is typed key "timeout".
+	TimeoutKey = goerr.NewTypedKey[time.Duration]("timeout")
+)
+
+// ErrUserNotFound is sentinel error of "user_not_found". Errors created by NewUserNotFound and WrapUserNotFound match it with errors.Is.
+// The user specified by user_id does not exist.
+var ErrUserNotFound = goerr.Sentinel("user_not_found", "user not found", goerr.T(TagNotFound))
+
+// NewUserNotFound creates a new error of "user_not_found".
+func NewUserNotFound(userID string, options ...goerr.Option) *goerr.Error {
+	return goerr.New("user not found", append([]goerr.Option{
+		goerr.ID("user_not_found"),
+		goerr.T(TagNotFound),
+		goerr.TV(UserIDKey, userID),
+		goerr.Unstack(1),
+	}, options...)...)
+}
+
+// WrapUserNotFound creates a new error of "user_not_found" with cause.
+func WrapUserNotFound(cause error, userID string, options ...goerr.Option) *goerr.Error {
+	return goerr.Wrap(cause, "user not found", append([]goerr.Option{
+		goerr.ID("user_not_found"),
+		goerr.T(TagNotFound),
+		goerr.TV(UserIDKey, userID),
+		goerr.Unstack(1),
+	}, options...)...)
+}
+
+// ErrDBTimeout is sentinel error of "db_timeout". Errors created by NewDBTimeout and WrapDBTimeout match it with errors.Is.
+var ErrDBTimeout = goerr.Sentinel("db_timeout", "database operation timed out", goerr.T(TagRetryable))
+
+// NewDBTimeout creates a new error of "db_timeout".
+func NewDBTimeout(timeout time.Duration, options ...goerr.Option) *goerr.Error {
+	return goerr.New("database operation timed out", append([]goerr.Option{
+		goerr.ID("db_timeout"),
+		goerr.T(TagRetryable),
+		goerr.TV(TimeoutKey, timeout),
+		goerr.Unstack(1),
+	}, options...)...)
+}
+
+// WrapDBTimeout creates a new error of "db_timeout" with cause.
+func WrapDBTimeout(cause error, timeout time.Duration, options ...goerr.Option) *goerr.Error {
+	return goerr.Wrap(cause, "database operation timed out", append([]goerr.Option{
+		goerr.ID("db_timeout"),
+		goerr.T(TagRetryable),
+		goerr.TV(TimeoutKey, timeout),
+		goerr.Unstack(1),
+	}, options...)...)
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+
+	"github.com/m-mizutani/goerr/v2"
+)
+
+//go:generate go run -C ../../cmd/goerr-gen . -input ../../examples/codegen/errors.yaml -output ../../examples/codegen/errors_gen.go -doc ../../examples/codegen/ERRORS.md
+
+func findUser(id string) error {
+	return WrapUserNotFound(sql.ErrNoRows, id)
+}
+
+func main() {
+	err := findUser("user123")
+
+	if errors.Is(err, ErrUserNotFound) && goerr.HasTag(err, TagNotFound) {
+		userID, _ := goerr.GetTypedValue(err, UserIDKey)
+		log.Printf("user not found: %s", userID)
+	}
+
+	log.Printf("%+v", err)
+}
@@ -0,0 +1,62 @@
+package goerr
+
+import "sync/atomic"
+
+var expectedStacks atomic.Bool
+
+// Expected marks the error as expected, i.e. a part of normal flow such as a validation failure or a not found resource. If any error in a chain is expected, LogValue, Printable and Format with %+v omit stack traces of the whole chain unless SetExpectedStacks(true) is called, and LogLevel returns slog.LevelInfo unless severity is set explicitly.
+//
+// Usage:
+//
+//	if user == nil {
+//		return goerr.New("user not found", goerr.Expected(), goerr.V("user_id", id))
+//	}
+func Expected() Option {
+	return func(err *Error) {
+		err.expected = true
+	}
+}
+
+// IsExpected returns true if x or one of its wrapped errors is marked by Expected option.
+func (x *Error) IsExpected() bool {
+	for _, e := range x.chain() {
+		if e.expected {
+			return true
+		}
+	}
+	return false
+}
+
+// IsExpected returns true if err is marked by Expected option. err can be *Error, *Errors or an error wrapping them. *Error wrapping *Errors is expected if the *Error is marked, or if all errors of the *Errors are expected.
+func IsExpected(err error) bool {
+	levels, errs := outerLevels(err)
+	for _, e := range levels {
+		if e.expected {
+			return true
+		}
+	}
+	if errs == nil || len(errs.errs) == 0 {
+		return false
+	}
+	for _, e := range errs.errs {
+		if !IsExpected(e) {
+			return false
+		}
+	}
+	return true
+}
+
+// SetExpectedStacks enables or disables stack traces of expected errors in LogValue, Printable and Format with %+v. It is disabled by default. Stacks() always returns stack traces regardless of this setting.
+func SetExpectedStacks(enabled bool) {
+	expectedStacks.Store(enabled)
+}
+
+// ExpectedStacks returns true if stack traces of expected errors are enabled.
+func ExpectedStacks() bool {
+	return expectedStacks.Load()
+}
+
+// omitStack returns true if stack traces of x should be omitted from output.
+func (x *Error) omitStack() bool {
+	return !ExpectedStacks() && x.IsExpected()
+}
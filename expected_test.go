@@ -0,0 +1,165 @@
+package goerr_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/m-mizutani/goerr/v2"
+)
+
+func TestIsExpected(t *testing.T) {
+	testCases := map[string]struct {
+		err  error
+		want bool
+	}{
+		"not expected": {
+			err:  goerr.New("failed"),
+			want: false,
+		},
+		"expected": {
+			err:  goerr.New("not found", goerr.Expected()),
+			want: true,
+		},
+		"inner level": {
+			err:  goerr.Wrap(goerr.New("not found", goerr.Expected()), "failed"),
+			want: true,
+		},
+		"outer level": {
+			err:  goerr.Wrap(errors.New("invalid"), "validation failed", goerr.Expected()),
+			want: true,
+		},
+		"through fmt.Errorf": {
+			err:  fmt.Errorf("outer: %w", goerr.New("not found", goerr.Expected())),
+			want: true,
+		},
+		"with copy": {
+			err:  goerr.With(goerr.New("not found", goerr.Expected()), goerr.V("k", "v")),
+			want: true,
+		},
+		"all errors expected": {
+			err:  goerr.Join(goerr.New("a", goerr.Expected()), goerr.New("b", goerr.Expected())),
+			want: true,
+		},
+		"some errors not expected": {
+			err:  goerr.Join(goerr.New("a", goerr.Expected()), goerr.New("b")),
+			want: false,
+		},
+		"standard error": {
+			err:  errors.New("failed"),
+			want: false,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if got := goerr.IsExpected(tc.err); got != tc.want {
+				t.Errorf("Expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestExpectedOmitsStacks(t *testing.T) {
+	err := goerr.Wrap(goerr.New("not found", goerr.Expected()), "failed to get user")
+
+	t.Run("format", func(t *testing.T) {
+		out := fmt.Sprintf("%+v", err)
+		if strings.Contains(out, "expected_test.go") {
+			t.Errorf("Expected no stack trace, got %s", out)
+		}
+		if !strings.HasPrefix(out, "failed to get user: not found") {
+			t.Errorf("Unexpected output: %s", out)
+		}
+	})
+
+	t.Run("printable", func(t *testing.T) {
+		p := err.Printable()
+		if p.StackTrace != nil || p.Cause.(*goerr.Printable).StackTrace != nil {
+			t.Error("Expected no stack trace in printable")
+		}
+		if !p.Expected {
+			t.Error("Expected expected mark in printable")
+		}
+	})
+
+	t.Run("log value", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := slog.New(slog.NewJSONHandler(&buf, nil))
+		logger.Error("failed", slog.Any("error", err))
+
+		var out struct {
+			Error struct {
+				Expected   bool     `json:"expected"`
+				StackTrace []string `json:"stacktrace"`
+				Cause      struct {
+					StackTrace []string `json:"stacktrace"`
+				} `json:"cause"`
+			} `json:"error"`
+		}
+		if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+			t.Fatal(err)
+		}
+		if !out.Error.Expected {
+			t.Errorf("Expected expected mark, got %s", buf.String())
+		}
+		if len(out.Error.StackTrace) != 0 || len(out.Error.Cause.StackTrace) != 0 {
+			t.Errorf("Expected no stack trace, got %s", buf.String())
+		}
+	})
+
+	t.Run("stacks", func(t *testing.T) {
+		if len(err.Stacks()) == 0 {
+			t.Error("Expected Stacks() to return stack trace")
+		}
+	})
+}
+
+func TestExpectedStacks(t *testing.T) {
+	goerr.SetExpectedStacks(true)
+	t.Cleanup(func() { goerr.SetExpectedStacks(false) })
+
+	err := goerr.New("not found", goerr.Expected())
+	if out := fmt.Sprintf("%+v", err); !strings.Contains(out, "expected_test.go") {
+		t.Errorf("Expected stack trace, got %s", out)
+	}
+	if p := err.Printable(); p.StackTrace == nil {
+		t.Error("Expected stack trace in printable")
+	}
+}
+
+func TestExpectedLogLevel(t *testing.T) {
+	if got := goerr.LogLevel(goerr.New("not found", goerr.Expected())); got != slog.LevelInfo {
+		t.Errorf("Expected %v, got %v", slog.LevelInfo, got)
+	}
+
+	err := goerr.New("not found", goerr.Expected(), goerr.Severity(goerr.SeverityWarn))
+	if got := goerr.LogLevel(err); got != slog.LevelWarn {
+		t.Errorf("Expected explicit severity %v, got %v", slog.LevelWarn, got)
+	}
+}
+
+func TestIsExpectedWrappingErrors(t *testing.T) {
+	errs := goerr.Join(goerr.New("a"), goerr.New("b"))
+
+	outer := goerr.Wrap(errs, "batch", goerr.Expected())
+	if !goerr.IsExpected(outer) {
+		t.Error("Expected outer error to be expected")
+	}
+	if got := goerr.LogLevel(outer); got != slog.LevelInfo {
+		t.Errorf("Expected level to be downgraded, got %v", got)
+	}
+
+	if goerr.IsExpected(goerr.Wrap(errs, "batch")) {
+		t.Error("Expected not expected error")
+	}
+
+	expectedErrs := goerr.Join(goerr.New("a", goerr.Expected()), goerr.New("b", goerr.Expected()))
+	if !goerr.IsExpected(goerr.Wrap(expectedErrs, "batch")) {
+		t.Error("Expected error wrapping expected errors to be expected")
+	}
+}
@@ -0,0 +1,223 @@
+// Package goerrtest provides test helpers to assert tags, values, IDs and origins of goerr errors. All helpers report failures via testing.TB with readable messages and continue the test like t.Errorf.
+//
+// Usage:
+//
+//	func TestFindUser(t *testing.T) {
+//		_, err := FindUser("unknown")
+//		goerrtest.AssertTag(t, err, TagNotFound)
+//		goerrtest.AssertValue(t, err, "user_id", "unknown")
+//		goerrtest.AssertOrigin(t, err, "repo.FindUser")
+//	}
+package goerrtest
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/m-mizutani/goerr/v2"
+)
+
+// AssertTag asserts that err or its wrapped errors have the tag. tag is usually created by goerr.NewTag.
+func AssertTag(t testing.TB, err error, tag fmt.Stringer) bool {
+	t.Helper()
+	if !checkError(t, err) {
+		return false
+	}
+
+	tags := tagNames(err)
+	for _, name := range tags {
+		if name == tag.String() {
+			return true
+		}
+	}
+
+	t.Errorf("goerrtest: tag %q not found\n  error: %v\n  tags:  %v", tag.String(), err, tags)
+	return false
+}
+
+// AssertNoTag asserts that neither err nor its wrapped errors have the tag.
+func AssertNoTag(t testing.TB, err error, tag fmt.Stringer) bool {
+	t.Helper()
+	if !checkError(t, err) {
+		return false
+	}
+
+	for _, name := range tagNames(err) {
+		if name == tag.String() {
+			t.Errorf("goerrtest: unexpected tag %q\n  error: %v", tag.String(), err)
+			return false
+		}
+	}
+	return true
+}
+
+// AssertValue asserts that value of key set by goerr.Value in err or its wrapped errors equals to want. Values are compared by reflect.DeepEqual.
+func AssertValue(t testing.TB, err error, key string, want any) bool {
+	t.Helper()
+	if !checkError(t, err) {
+		return false
+	}
+
+	values := goerr.Values(err)
+	got, ok := values[key]
+	if !ok {
+		t.Errorf("goerrtest: value %q not found\n  error:  %v\n  values: %s", key, err, formatMap(values))
+		return false
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("goerrtest: value %q mismatch\n  error: %v\n  want:  %#v\n  got:   %#v", key, err, want, got)
+		return false
+	}
+	return true
+}
+
+// AssertTypedValue asserts that typed value of key in err or its wrapped errors equals to want. Values are compared by reflect.DeepEqual.
+func AssertTypedValue[T any](t testing.TB, err error, key goerr.TypedKey[T], want T) bool {
+	t.Helper()
+	if !checkError(t, err) {
+		return false
+	}
+
+	got, ok := goerr.GetTypedValue(err, key)
+	if !ok {
+		t.Errorf("goerrtest: typed value %q not found\n  error:        %v\n  typed values: %s", key.Name(), err, formatMap(goerr.TypedValues(err)))
+		return false
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("goerrtest: typed value %q mismatch\n  error: %v\n  want:  %#v\n  got:   %#v", key.Name(), err, want, got)
+		return false
+	}
+	return true
+}
+
+// AssertID asserts that err matches the ID set by goerr.ID or goerr.Sentinel with errors.Is.
+func AssertID(t testing.TB, err error, id string) bool {
+	t.Helper()
+	if !checkError(t, err) {
+		return false
+	}
+
+	if errors.Is(err, goerr.Sentinel(id, "")) {
+		return true
+	}
+
+	t.Errorf("goerrtest: ID %q not found\n  error: %v\n  IDs:   %v", id, err, chainIDs(err))
+	return false
+}
+
+// AssertOrigin asserts that the innermost stack trace of err starts at function fn. fn can be a full name ("github.com/foo/bar/pkg.Func"), a name with the last path element ("pkg.Func", "pkg.(*Type).Method") or a name without package ("Func").
+func AssertOrigin(t testing.TB, err error, fn string) bool {
+	t.Helper()
+	if !checkError(t, err) {
+		return false
+	}
+
+	stacks := originStacks(err)
+	if len(stacks) == 0 {
+		t.Errorf("goerrtest: no stack trace found\n  error: %v", err)
+		return false
+	}
+
+	if matchFunc(stacks[0].Func, fn) {
+		return true
+	}
+
+	t.Errorf("goerrtest: origin mismatch\n  error: %v\n  want:  %s\n  got:   %s (%s:%d)", err, fn, stacks[0].Func, stacks[0].File, stacks[0].Line)
+	return false
+}
+
+func checkError(t testing.TB, err error) bool {
+	t.Helper()
+	if err == nil {
+		t.Errorf("goerrtest: expected error, but got nil")
+		return false
+	}
+	return true
+}
+
+// tagNames returns all tag names in err, including errors in *goerr.Errors.
+func tagNames(err error) []string {
+	var names []string
+	if errs := goerr.AsErrors(err); errs != nil {
+		for _, e := range errs.Errors() {
+			names = append(names, tagNames(e)...)
+		}
+	}
+	names = append(names, goerr.Tags(err)...)
+	sort.Strings(names)
+	return names
+}
+
+// chainIDs returns IDs of all *goerr.Error in err.
+func chainIDs(err error) []string {
+	var ids []string
+	e := goerr.Unwrap(err)
+	if e == nil {
+		return nil
+	}
+	for p := e.Printable(); p != nil; {
+		if p.ID != "" {
+			ids = append(ids, p.ID)
+		}
+		next, ok := p.Cause.(*goerr.Printable)
+		if !ok {
+			break
+		}
+		p = next
+	}
+	return ids
+}
+
+// originStacks returns the innermost stack trace in err. It reads Stacks() of each *goerr.Error instead of Printable, which omits stack traces of expected errors and in deferred symbolization mode.
+func originStacks(err error) []*goerr.Stack {
+	var stacks []*goerr.Stack
+	visited := make(map[*goerr.Error]struct{})
+	for e := goerr.Unwrap(err); e != nil && len(visited) < goerr.MaxChainDepth(); e = goerr.Unwrap(e.Unwrap()) {
+		if _, ok := visited[e]; ok {
+			break
+		}
+		visited[e] = struct{}{}
+
+		if st := e.Stacks(); len(st) > 0 {
+			stacks = st
+		}
+	}
+	return stacks
+}
+
+// matchFunc returns true if full function name matches fn at a boundary of path or package.
+func matchFunc(full, fn string) bool {
+	if full == fn {
+		return true
+	}
+	if strings.HasSuffix(full, "/"+fn) {
+		return true
+	}
+	// Name without package, e.g. "Func" or "(*Type).Method"
+	base := full[strings.LastIndex(full, "/")+1:]
+	if i := strings.Index(base, "."); i >= 0 && base[i+1:] == fn {
+		return true
+	}
+	return false
+}
+
+func formatMap(m map[string]any) string {
+	if len(m) == 0 {
+		return "(none)"
+	}
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for _, k := range keys {
+		fmt.Fprintf(&b, "\n    %s: %#v", k, m[k])
+	}
+	return b.String()
+}
@@ -0,0 +1,191 @@
+package goerrtest_test
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/m-mizutani/goerr/v2"
+	"github.com/m-mizutani/goerr/v2/goerrtest"
+)
+
+// recorder records failures instead of failing the test
+type recorder struct {
+	testing.TB
+	messages []string
+}
+
+func (x *recorder) Helper() {}
+
+func (x *recorder) Errorf(format string, args ...any) {
+	x.messages = append(x.messages, fmt.Sprintf(format, args...))
+}
+
+func (x *recorder) failed(t *testing.T, contains string) {
+	t.Helper()
+	if len(x.messages) != 1 {
+		t.Fatalf("Expected 1 failure, got %d: %v", len(x.messages), x.messages)
+	}
+	if !strings.Contains(x.messages[0], contains) {
+		t.Errorf("Expected failure message to contain %q, got:\n%s", contains, x.messages[0])
+	}
+}
+
+var (
+	tagNotFound = goerr.NewTag("not_found")
+	tagRetry    = goerr.NewTag("retry")
+	userIDKey   = goerr.NewTypedKey[string]("user_id")
+)
+
+func findUser(id string) error {
+	return goerr.New("user not found",
+		goerr.ID("user_not_found"),
+		goerr.T(tagNotFound),
+		goerr.V("id", id),
+		goerr.TV(userIDKey, id),
+	)
+}
+
+func handle(id string) error {
+	if err := findUser(id); err != nil {
+		return goerr.Wrap(err, "failed to handle", goerr.V("handler", "get"))
+	}
+	return nil
+}
+
+func TestAssertSuccess(t *testing.T) {
+	err := handle("u1")
+
+	goerrtest.AssertTag(t, err, tagNotFound)
+	goerrtest.AssertNoTag(t, err, tagRetry)
+	goerrtest.AssertValue(t, err, "id", "u1")
+	goerrtest.AssertValue(t, err, "handler", "get")
+	goerrtest.AssertTypedValue(t, err, userIDKey, "u1")
+	goerrtest.AssertID(t, err, "user_not_found")
+	goerrtest.AssertOrigin(t, err, "goerrtest_test.findUser")
+	goerrtest.AssertOrigin(t, err, "findUser")
+	goerrtest.AssertOrigin(t, err, "github.com/m-mizutani/goerr/v2/goerrtest_test.findUser")
+
+	// Tags in *goerr.Errors
+	goerrtest.AssertTag(t, goerr.Join(errors.New("plain"), err), tagNotFound)
+}
+
+func TestAssertFailure(t *testing.T) {
+	err := handle("u1")
+
+	testCases := map[string]struct {
+		assert   func(t testing.TB) bool
+		contains string
+	}{
+		"tag": {
+			assert:   func(t testing.TB) bool { return goerrtest.AssertTag(t, err, tagRetry) },
+			contains: `tag "retry" not found`,
+		},
+		"no tag": {
+			assert:   func(t testing.TB) bool { return goerrtest.AssertNoTag(t, err, tagNotFound) },
+			contains: `unexpected tag "not_found"`,
+		},
+		"value missing": {
+			assert:   func(t testing.TB) bool { return goerrtest.AssertValue(t, err, "missing", 1) },
+			contains: "handler: \"get\"",
+		},
+		"value mismatch": {
+			assert:   func(t testing.TB) bool { return goerrtest.AssertValue(t, err, "id", "u2") },
+			contains: "want:  \"u2\"\n  got:   \"u1\"",
+		},
+		"typed value": {
+			assert:   func(t testing.TB) bool { return goerrtest.AssertTypedValue(t, err, userIDKey, "u2") },
+			contains: `typed value "user_id" mismatch`,
+		},
+		"id": {
+			assert:   func(t testing.TB) bool { return goerrtest.AssertID(t, err, "other") },
+			contains: "IDs:   [user_not_found]",
+		},
+		"origin": {
+			assert:   func(t testing.TB) bool { return goerrtest.AssertOrigin(t, err, "pkg.handle") },
+			contains: "got:   github.com/m-mizutani/goerr/v2/goerrtest_test.findUser",
+		},
+		"partial name": {
+			assert:   func(t testing.TB) bool { return goerrtest.AssertOrigin(t, err, "User") },
+			contains: "origin mismatch",
+		},
+		"nil": {
+			assert:   func(t testing.TB) bool { return goerrtest.AssertTag(t, nil, tagRetry) },
+			contains: "expected error, but got nil",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			r := &recorder{TB: t}
+			if tc.assert(r) {
+				t.Error("Expected assertion to fail")
+			}
+			r.failed(t, tc.contains)
+		})
+	}
+}
+
+func TestAssertGolden(t *testing.T) {
+	err := handle("u1")
+	goerrtest.AssertGolden(t, err, "testdata/handle.golden.json")
+	if os.Getenv(goerrtest.UpdateGoldenEnv) != "" {
+		return
+	}
+
+	// Mismatch shows diff
+	r := &recorder{TB: t}
+	if goerrtest.AssertGolden(r, handle("u2"), "testdata/handle.golden.json") {
+		t.Error("Expected assertion to fail")
+	}
+	r.failed(t, "-     \"id\": \"u1\"\n+     \"id\": \"u2\"")
+}
+
+func TestAssertGoldenUpdate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sub", "update.golden.json")
+	t.Setenv(goerrtest.UpdateGoldenEnv, "1")
+	if !goerrtest.AssertGolden(t, handle("u1"), path) {
+		t.Fatal("Expected golden file to be updated")
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv(goerrtest.UpdateGoldenEnv, "")
+	goerrtest.AssertGolden(t, handle("u1"), path)
+}
+
+func TestAssertGoldenMultipleTags(t *testing.T) {
+	newTagged := func() error {
+		return goerr.New("failed", goerr.T(tagNotFound), goerr.T(tagRetry), goerr.T(goerr.NewTag("fatal")))
+	}
+
+	path := filepath.Join(t.TempDir(), "tags.golden.json")
+	t.Setenv(goerrtest.UpdateGoldenEnv, "1")
+	goerrtest.AssertGolden(t, newTagged(), path)
+
+	// Map iteration order differs between calls, so repeat to catch order dependency
+	t.Setenv(goerrtest.UpdateGoldenEnv, "")
+	for i := 0; i < 20; i++ {
+		if !goerrtest.AssertGolden(t, newTagged(), path) {
+			break
+		}
+	}
+}
+
+func TestAssertOriginWithoutPrintableStacks(t *testing.T) {
+	t.Run("expected", func(t *testing.T) {
+		err := goerr.Wrap(goerr.New("not found", goerr.Expected()), "failed")
+		goerrtest.AssertOrigin(t, err, "TestAssertOriginWithoutPrintableStacks.func1")
+	})
+
+	t.Run("deferred symbolization", func(t *testing.T) {
+		goerr.SetDeferredSymbolization(true)
+		t.Cleanup(func() { goerr.SetDeferredSymbolization(false) })
+
+		goerrtest.AssertOrigin(t, handle("u1"), "findUser")
+	})
+}
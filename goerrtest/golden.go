@@ -0,0 +1,131 @@
+package goerrtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/m-mizutani/goerr/v2"
+)
+
+// UpdateGoldenEnv is the environment variable to update golden files. If it is set to non-empty value, AssertGolden writes actual output to the golden file instead of comparing.
+//
+//	GOERRTEST_UPDATE=1 go test ./...
+const UpdateGoldenEnv = "GOERRTEST_UPDATE"
+
+// AssertGolden compares JSON of (*goerr.Error).Printable with the golden file. Stack traces are normalised to keep golden files stable: frames of runtime and testing packages are removed, file paths are reduced to base names and line numbers are set to zero. Tags are sorted.
+func AssertGolden(t testing.TB, err error, path string) bool {
+	t.Helper()
+	if !checkError(t, err) {
+		return false
+	}
+
+	got, marshalErr := marshalGolden(err)
+	if marshalErr != nil {
+		t.Errorf("goerrtest: failed to marshal error: %v", marshalErr)
+		return false
+	}
+
+	if os.Getenv(UpdateGoldenEnv) != "" {
+		if mkErr := os.MkdirAll(filepath.Dir(path), 0755); mkErr != nil {
+			t.Errorf("goerrtest: failed to create directory of golden file: %v", mkErr)
+			return false
+		}
+		if wErr := os.WriteFile(path, got, 0644); wErr != nil {
+			t.Errorf("goerrtest: failed to update golden file: %v", wErr)
+			return false
+		}
+		return true
+	}
+
+	want, readErr := os.ReadFile(filepath.Clean(path))
+	if readErr != nil {
+		t.Errorf("goerrtest: failed to read golden file (set %s=1 to create it): %v", UpdateGoldenEnv, readErr)
+		return false
+	}
+
+	if !bytes.Equal(want, got) {
+		t.Errorf("goerrtest: golden file %s mismatch (-want +got):\n%s", path, lineDiff(string(want), string(got)))
+		return false
+	}
+	return true
+}
+
+// marshalGolden returns normalised JSON of err.
+func marshalGolden(err error) ([]byte, error) {
+	var v any = err.Error()
+	if e := goerr.Unwrap(err); e != nil {
+		p := e.Printable()
+		normalizePrintable(p)
+		v = p
+	}
+
+	raw, marshalErr := json.MarshalIndent(v, "", "  ")
+	if marshalErr != nil {
+		return nil, marshalErr
+	}
+	return append(raw, '\n'), nil
+}
+
+func normalizePrintable(p *goerr.Printable) {
+	for ; p != nil; p, _ = p.Cause.(*goerr.Printable) {
+		stacks := make([]*goerr.Stack, 0, len(p.StackTrace))
+		for _, st := range p.StackTrace {
+			if strings.HasPrefix(st.Func, "runtime.") || strings.HasPrefix(st.Func, "testing.") {
+				continue
+			}
+			stacks = append(stacks, &goerr.Stack{
+				Func: st.Func,
+				File: filepath.Base(st.File),
+			})
+		}
+		p.StackTrace = stacks
+		// Tags are stored in a map, so the order is not stable
+		sort.Strings(p.Tags)
+	}
+}
+
+// lineDiff returns a line-based diff of want and got. Lines only in want are prefixed with "-", lines only in got are prefixed with "+".
+func lineDiff(want, got string) string {
+	a := strings.Split(want, "\n")
+	b := strings.Split(got, "\n")
+
+	// Longest common subsequence table
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var out strings.Builder
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			out.WriteString("  " + a[i] + "\n")
+			i++
+			j++
+		case i < len(a) && (j >= len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			out.WriteString("- " + a[i] + "\n")
+			i++
+		default:
+			out.WriteString("+ " + b[j] + "\n")
+			j++
+		}
+	}
+	return out.String()
+}
@@ -0,0 +1,168 @@
+// Package buildid reads Go build ID of a binary. It is shared by deferred symbolization of goerr and `goerr symbolize` command to compare build IDs of the logging binary and the symbolizing binary.
+//
+// It parses ELF headers by itself instead of debug/elf, because debug/elf links debug/dwarf and compression packages into every binary importing goerr.
+package buildid
+
+import (
+	"encoding/binary"
+	"io"
+	"os"
+)
+
+// sectionName is the name of ELF section that has Go build ID as a note.
+const sectionName = ".note.go.buildid"
+
+// maxNoteSize is maximum size of the build ID note to read. Go build ID is less than 100 bytes.
+const maxNoteSize = 4096
+
+// ReadFile returns Go build ID of ELF binary at path. It returns empty string if the file is not ELF binary or has no build ID.
+func ReadFile(path string) string {
+	f, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+
+	return Read(f)
+}
+
+// Read returns Go build ID in .note.go.buildid section of ELF binary r. It returns empty string if r is not ELF binary or has no build ID.
+func Read(r io.ReaderAt) string {
+	ident := make([]byte, 16)
+	if _, err := r.ReadAt(ident, 0); err != nil || string(ident[:4]) != "\x7fELF" {
+		return ""
+	}
+
+	var order binary.ByteOrder
+	switch ident[5] {
+	case 1:
+		order = binary.LittleEndian
+	case 2:
+		order = binary.BigEndian
+	default:
+		return ""
+	}
+
+	// Offsets in ELF header and section header differ between 32-bit and 64-bit
+	var h elfLayout
+	switch ident[4] {
+	case 1:
+		h = elf32
+	case 2:
+		h = elf64
+	default:
+		return ""
+	}
+	h.order = order
+
+	header := make([]byte, h.headerSize)
+	if _, err := r.ReadAt(header, 0); err != nil {
+		return ""
+	}
+	shoff := h.uint(header[h.shoff:])
+	shentsize := uint64(order.Uint16(header[h.shentsize:]))
+	shnum := uint64(order.Uint16(header[h.shnum:]))
+	shstrndx := uint64(order.Uint16(header[h.shstrndx:]))
+	if shoff == 0 || shentsize < h.sectionSize || shstrndx >= shnum {
+		return ""
+	}
+
+	readSection := func(i uint64) (name uint32, offset, size uint64, ok bool) {
+		sh := make([]byte, h.sectionSize)
+		if _, err := r.ReadAt(sh, int64(shoff+i*shentsize)); err != nil {
+			return 0, 0, 0, false
+		}
+		return order.Uint32(sh[0:4]), h.uint(sh[h.offset:]), h.uint(sh[h.size:]), true
+	}
+
+	_, strOffset, strSize, ok := readSection(shstrndx)
+	if !ok {
+		return ""
+	}
+	for i := uint64(0); i < shnum; i++ {
+		name, offset, size, ok := readSection(i)
+		if !ok {
+			return ""
+		}
+		if uint64(name)+uint64(len(sectionName))+1 > strSize || size > maxNoteSize {
+			continue
+		}
+
+		nameBuf := make([]byte, len(sectionName)+1)
+		if _, err := r.ReadAt(nameBuf, int64(strOffset+uint64(name))); err != nil {
+			return ""
+		}
+		if string(nameBuf) != sectionName+"\x00" {
+			continue
+		}
+
+		data := make([]byte, size)
+		if _, err := r.ReadAt(data, int64(offset)); err != nil {
+			return ""
+		}
+		return parseNote(data, order)
+	}
+
+	return ""
+}
+
+// parseNote returns description of ELF note in data.
+func parseNote(data []byte, order binary.ByteOrder) string {
+	if len(data) < 16 {
+		return ""
+	}
+
+	// Note layout: namesz, descsz, type, name ("Go\x00\x00"), desc (build ID)
+	nameSize := int(order.Uint32(data[0:4]))
+	descSize := int(order.Uint32(data[4:8]))
+	descStart := 12 + (nameSize+3)&^3
+	if descStart+descSize > len(data) {
+		return ""
+	}
+	return string(data[descStart : descStart+descSize])
+}
+
+// elfLayout is offsets of fields in ELF header and section header.
+type elfLayout struct {
+	order      binary.ByteOrder
+	is64       bool
+	headerSize int
+
+	// ELF header
+	shoff, shentsize, shnum, shstrndx int
+
+	// Section header
+	sectionSize  uint64
+	offset, size int
+}
+
+var elf32 = elfLayout{
+	headerSize:  52,
+	shoff:       0x20,
+	shentsize:   0x2E,
+	shnum:       0x30,
+	shstrndx:    0x32,
+	sectionSize: 40,
+	offset:      16,
+	size:        20,
+}
+
+var elf64 = elfLayout{
+	is64:        true,
+	headerSize:  64,
+	shoff:       0x28,
+	shentsize:   0x3A,
+	shnum:       0x3C,
+	shstrndx:    0x3E,
+	sectionSize: 64,
+	offset:      24,
+	size:        32,
+}
+
+// uint reads an address or offset, which is 4 bytes in 32-bit ELF and 8 bytes in 64-bit ELF.
+func (x elfLayout) uint(b []byte) uint64 {
+	if x.is64 {
+		return x.order.Uint64(b)
+	}
+	return uint64(x.order.Uint32(b))
+}
@@ -0,0 +1,50 @@
+package buildid_test
+
+import (
+	"debug/elf"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/m-mizutani/goerr/v2/internal/buildid"
+)
+
+func TestReadFile(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("test binary is not ELF")
+	}
+	path, err := os.Executable()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Compare with the note read by debug/elf
+	f, err := elf.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	data, err := f.Section(".note.go.buildid").Data()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := buildid.ReadFile(path)
+	if got == "" || len(data) < 16+len(got) || string(data[16:16+len(got)]) != got {
+		t.Errorf("Unexpected build ID %q, note is %q", got, data)
+	}
+}
+
+func TestReadFileNotELF(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "not_elf")
+	if err := os.WriteFile(path, []byte("#!/bin/sh\necho hello\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if got := buildid.ReadFile(path); got != "" {
+		t.Errorf("Expected empty build ID, got %q", got)
+	}
+	if got := buildid.ReadFile(filepath.Join(t.TempDir(), "not_exist")); got != "" {
+		t.Errorf("Expected empty build ID, got %q", got)
+	}
+}
@@ -0,0 +1,190 @@
+package goerr
+
+import (
+	"context"
+	"log/slog"
+)
+
+// MarkLogged marks err as logged to avoid logging the same failure repeatedly in multiple layers. Only the outermost *Error of err is marked, and IsLogged of errors wrapping it returns true. The mark is shared with copies created by With, but not with errors created by (*Error).Wrap, so wrapping a package-level error never marks the package-level error itself. err can be *Error, *Errors or an error wrapping them. It does nothing for other errors and sentinel errors.
+//
+// Usage:
+//
+//	if err := svc.Do(ctx); err != nil {
+//		logger.Error("failed", slog.Any("error", err))
+//		goerr.MarkLogged(err)
+//		return err
+//	}
+func MarkLogged(err error) {
+	levels, errs := outerLevels(err)
+	if len(levels) > 0 {
+		if levels[0].logged != nil {
+			levels[0].logged.Store(true)
+		}
+		return
+	}
+	if errs != nil {
+		for _, e := range errs.errs {
+			MarkLogged(e)
+		}
+	}
+}
+
+// IsLogged returns true if err or one of its wrapped errors is marked by MarkLogged. *Errors is logged only if all of its errors are logged.
+func IsLogged(err error) bool {
+	levels, errs := outerLevels(err)
+	for _, e := range levels {
+		if e.logged != nil && e.logged.Load() {
+			return true
+		}
+	}
+	if errs == nil || len(errs.errs) == 0 {
+		return false
+	}
+	for _, e := range errs.errs {
+		if !IsLogged(e) {
+			return false
+		}
+	}
+	return true
+}
+
+// LogHandlerOptions is options of LogHandler.
+type LogHandlerOptions struct {
+	// Collapse makes the handler output a record whose errors are already logged with only message of the errors, instead of skipping the record.
+	Collapse bool
+	// Force makes the handler output all records with full error details regardless of the mark. It is intended for the top-level handler, e.g. of a request logger. Errors are still marked after output. Use ContextWithForceLog to force output of a single record.
+	Force bool
+}
+
+type forceLogCtxKey struct{}
+
+// ContextWithForceLog returns a copy of ctx that makes LogHandler output records logged with the context regardless of the mark.
+//
+// Usage:
+//
+//	// In the top-level HTTP middleware
+//	logger.ErrorContext(goerr.ContextWithForceLog(ctx), "request failed", slog.Any("error", err))
+func ContextWithForceLog(ctx context.Context) context.Context {
+	return context.WithValue(ctx, forceLogCtxKey{}, true)
+}
+
+func isForceLog(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+	force, _ := ctx.Value(forceLogCtxKey{}).(bool)
+	return force
+}
+
+// LogHandler is slog.Handler that avoids duplicated logging of the same error. It finds errors in attributes of a record, including attributes in groups and attributes added by Logger.With, skips the record if all of the errors are already marked by MarkLogged, and marks the errors after passing the record to the next handler.
+type LogHandler struct {
+	next slog.Handler
+	opts LogHandlerOptions
+	errs []error // errors in attributes added by WithAttrs
+}
+
+var _ slog.Handler = (*LogHandler)(nil)
+
+// NewLogHandler creates a LogHandler that passes records to next. opts can be nil.
+//
+// Usage:
+//
+//	logger := slog.New(goerr.NewLogHandler(slog.NewJSONHandler(os.Stdout, nil), nil))
+func NewLogHandler(next slog.Handler, opts *LogHandlerOptions) *LogHandler {
+	h := &LogHandler{next: next}
+	if opts != nil {
+		h.opts = *opts
+	}
+	return h
+}
+
+// Enabled implements slog.Handler.
+func (x *LogHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return x.next.Enabled(ctx, level)
+}
+
+// Handle implements slog.Handler.
+func (x *LogHandler) Handle(ctx context.Context, record slog.Record) error {
+	errs := append([]error(nil), x.errs...)
+	record.Attrs(func(attr slog.Attr) bool {
+		errs = appendAttrErrors(errs, attr)
+		return true
+	})
+
+	allLogged := true
+	for _, err := range errs {
+		allLogged = allLogged && IsLogged(err)
+	}
+
+	if len(errs) > 0 && allLogged && !x.opts.Force && !isForceLog(ctx) {
+		if !x.opts.Collapse {
+			return nil
+		}
+		record = collapseRecord(record)
+	}
+
+	if err := x.next.Handle(ctx, record); err != nil {
+		return err
+	}
+
+	for _, err := range errs {
+		MarkLogged(err)
+	}
+	return nil
+}
+
+// appendAttrErrors appends errors in attr, including errors in groups, to errs.
+func appendAttrErrors(errs []error, attr slog.Attr) []error {
+	if attr.Value.Kind() == slog.KindGroup {
+		for _, a := range attr.Value.Group() {
+			errs = appendAttrErrors(errs, a)
+		}
+		return errs
+	}
+	if err, ok := attr.Value.Any().(error); ok && err != nil {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// collapseRecord returns a copy of record whose error attributes have only message and logged mark. Errors in attributes added by WithAttrs are already passed to the next handler and not collapsed.
+func collapseRecord(record slog.Record) slog.Record {
+	collapsed := slog.NewRecord(record.Time, record.Level, record.Message, record.PC)
+	record.Attrs(func(attr slog.Attr) bool {
+		collapsed.AddAttrs(collapseAttr(attr))
+		return true
+	})
+	return collapsed
+}
+
+func collapseAttr(attr slog.Attr) slog.Attr {
+	if attr.Value.Kind() == slog.KindGroup {
+		group := attr.Value.Group()
+		attrs := make([]any, len(group))
+		for i, a := range group {
+			attrs[i] = collapseAttr(a)
+		}
+		return slog.Group(attr.Key, attrs...)
+	}
+	if err, ok := attr.Value.Any().(error); ok && err != nil {
+		return slog.Group(attr.Key,
+			slog.String("message", err.Error()),
+			slog.Bool("logged", true),
+		)
+	}
+	return attr
+}
+
+// WithAttrs implements slog.Handler. Errors in attrs are kept to be checked and marked by Handle.
+func (x *LogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	errs := append([]error(nil), x.errs...)
+	for _, attr := range attrs {
+		errs = appendAttrErrors(errs, attr)
+	}
+	return &LogHandler{next: x.next.WithAttrs(attrs), opts: x.opts, errs: errs}
+}
+
+// WithGroup implements slog.Handler.
+func (x *LogHandler) WithGroup(name string) slog.Handler {
+	return &LogHandler{next: x.next.WithGroup(name), opts: x.opts, errs: x.errs}
+}
@@ -0,0 +1,271 @@
+package goerr_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/m-mizutani/goerr/v2"
+)
+
+func TestMarkLogged(t *testing.T) {
+	t.Run("error", func(t *testing.T) {
+		err := goerr.New("failed")
+		if goerr.IsLogged(err) {
+			t.Fatal("Expected not logged")
+		}
+		goerr.MarkLogged(err)
+		if !goerr.IsLogged(err) {
+			t.Error("Expected logged")
+		}
+	})
+
+	t.Run("wrapped after mark", func(t *testing.T) {
+		err := goerr.New("failed")
+		goerr.MarkLogged(err)
+		if !goerr.IsLogged(goerr.Wrap(err, "outer")) {
+			t.Error("Expected wrapping error to be logged")
+		}
+		if !goerr.IsLogged(fmt.Errorf("outer: %w", err)) {
+			t.Error("Expected fmt.Errorf wrapping error to be logged")
+		}
+	})
+
+	t.Run("wrapped before mark", func(t *testing.T) {
+		inner := goerr.New("failed")
+		outer := goerr.Wrap(inner, "outer")
+		goerr.MarkLogged(outer)
+		if !goerr.IsLogged(goerr.Wrap(outer, "more outer")) {
+			t.Error("Expected error wrapping outer error to be logged")
+		}
+		// Only the outermost error is marked because inner error may be shared
+		if goerr.IsLogged(inner) {
+			t.Error("Expected inner error not to be marked")
+		}
+	})
+
+	t.Run("with copy", func(t *testing.T) {
+		err := goerr.New("failed")
+		copied := goerr.With(err, goerr.V("k", "v"))
+		goerr.MarkLogged(copied)
+		if !goerr.IsLogged(err) {
+			t.Error("Expected original error to share the mark")
+		}
+	})
+
+	t.Run("sentinel", func(t *testing.T) {
+		sentinel := goerr.Sentinel("logged_test", "sentinel")
+		goerr.MarkLogged(goerr.With(sentinel, goerr.V("k", "v")))
+		goerr.MarkLogged(sentinel)
+		if goerr.IsLogged(sentinel) {
+			t.Error("Expected sentinel error not to be marked")
+		}
+		if goerr.IsLogged(goerr.With(sentinel)) {
+			t.Error("Expected copy of sentinel error not to be marked")
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		a, b := goerr.New("a"), goerr.New("b")
+		errs := goerr.Join(a, b)
+		goerr.MarkLogged(a)
+		if goerr.IsLogged(errs) {
+			t.Error("Expected errors not to be logged until all errors are logged")
+		}
+		goerr.MarkLogged(errs)
+		if !goerr.IsLogged(errs) || !goerr.IsLogged(b) {
+			t.Error("Expected all errors to be logged")
+		}
+	})
+
+	t.Run("standard error", func(t *testing.T) {
+		err := errors.New("failed")
+		goerr.MarkLogged(err)
+		if goerr.IsLogged(err) {
+			t.Error("Expected standard error not to be marked")
+		}
+	})
+}
+
+var errLoggedTestNotFound = goerr.New("not found", goerr.ID("logged_test_not_found"))
+
+func TestMarkLoggedPackageLevelError(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(goerr.NewLogHandler(slog.NewTextHandler(&buf, nil), nil))
+
+	// Two unrelated requests wrap the same package-level error
+	first := goerr.Wrap(errLoggedTestNotFound, "request 1 failed")
+	logger.Error("request 1", slog.Any("error", first))
+	second := errLoggedTestNotFound.Wrap(nil, goerr.V("request", 2))
+	third := goerr.Wrap(errLoggedTestNotFound, "request 3 failed")
+
+	if goerr.IsLogged(errLoggedTestNotFound) {
+		t.Error("Expected package-level error not to be marked")
+	}
+	if goerr.IsLogged(second) || goerr.IsLogged(third) {
+		t.Error("Expected errors of other requests not to be logged")
+	}
+
+	logger.Error("request 2", slog.Any("error", second))
+	logger.Error("request 3", slog.Any("error", third))
+	for _, msg := range []string{"request 1", "request 2", "request 3"} {
+		if !strings.Contains(buf.String(), "msg=\""+msg+"\"") {
+			t.Errorf("Expected %q to be logged, got %s", msg, buf.String())
+		}
+	}
+}
+
+func TestLogHandler(t *testing.T) {
+	newLogger := func(opts *goerr.LogHandlerOptions) (*slog.Logger, *bytes.Buffer) {
+		var buf bytes.Buffer
+		return slog.New(goerr.NewLogHandler(slog.NewTextHandler(&buf, nil), opts)), &buf
+	}
+
+	t.Run("skip", func(t *testing.T) {
+		logger, buf := newLogger(nil)
+		err := goerr.New("failed")
+
+		logger.Error("first", slog.Any("error", err))
+		if !goerr.IsLogged(err) {
+			t.Fatal("Expected handler to mark the error")
+		}
+		logger.Error("second", slog.Any("error", goerr.Wrap(err, "outer")))
+		logger.Info("no error")
+
+		out := buf.String()
+		if !strings.Contains(out, "first") || strings.Contains(out, "second") || !strings.Contains(out, "no error") {
+			t.Errorf("Unexpected output: %s", out)
+		}
+	})
+
+	t.Run("collapse", func(t *testing.T) {
+		logger, buf := newLogger(&goerr.LogHandlerOptions{Collapse: true})
+		err := goerr.New("failed", goerr.V("user_id", "u1"))
+		goerr.MarkLogged(err)
+
+		logger.Error("again", slog.Any("error", err))
+		out := buf.String()
+		if !strings.Contains(out, "error.message=failed") || !strings.Contains(out, "error.logged=true") {
+			t.Errorf("Expected collapsed error, got %s", out)
+		}
+		if strings.Contains(out, "user_id") {
+			t.Errorf("Expected no values, got %s", out)
+		}
+	})
+
+	t.Run("force", func(t *testing.T) {
+		logger, buf := newLogger(&goerr.LogHandlerOptions{Force: true})
+		err := goerr.New("failed")
+		goerr.MarkLogged(err)
+
+		logger.Error("forced", slog.Any("error", err))
+		if !strings.Contains(buf.String(), "forced") {
+			t.Errorf("Expected forced output, got %s", buf.String())
+		}
+	})
+
+	t.Run("force by context", func(t *testing.T) {
+		logger, buf := newLogger(nil)
+		err := goerr.New("failed")
+		goerr.MarkLogged(err)
+
+		logger.ErrorContext(goerr.ContextWithForceLog(context.Background()), "forced", slog.Any("error", err))
+		if !strings.Contains(buf.String(), "forced") {
+			t.Errorf("Expected forced output, got %s", buf.String())
+		}
+	})
+
+	t.Run("error added by With", func(t *testing.T) {
+		logger, buf := newLogger(nil)
+		err := goerr.New("failed")
+
+		l := logger.With("error", err)
+		l.Error("first")
+		if !goerr.IsLogged(err) {
+			t.Fatal("Expected handler to mark the error added by With")
+		}
+		l.Error("second")
+		logger.With("error", goerr.Wrap(err, "outer")).WithGroup("g").Error("third")
+
+		out := buf.String()
+		if !strings.Contains(out, "first") || strings.Contains(out, "second") || strings.Contains(out, "third") {
+			t.Errorf("Unexpected output: %s", out)
+		}
+	})
+
+	t.Run("error in group", func(t *testing.T) {
+		logger, buf := newLogger(nil)
+		err := goerr.New("failed", goerr.V("user_id", "u1"))
+
+		logger.Error("first", slog.Group("request", slog.String("id", "r1"), slog.Any("error", err)))
+		if !goerr.IsLogged(err) {
+			t.Fatal("Expected handler to mark the error in group")
+		}
+		logger.Error("second", slog.Group("request", slog.Any("error", goerr.Wrap(err, "outer"))))
+
+		out := buf.String()
+		if !strings.Contains(out, "first") || strings.Contains(out, "second") {
+			t.Errorf("Unexpected output: %s", out)
+		}
+	})
+
+	t.Run("collapse in group", func(t *testing.T) {
+		logger, buf := newLogger(&goerr.LogHandlerOptions{Collapse: true})
+		err := goerr.New("failed", goerr.V("user_id", "u1"))
+		goerr.MarkLogged(err)
+
+		logger.Error("again", slog.Group("request", slog.String("id", "r1"), slog.Any("error", err)))
+		out := buf.String()
+		if !strings.Contains(out, "request.id=r1") || !strings.Contains(out, "request.error.message=failed") || !strings.Contains(out, "request.error.logged=true") {
+			t.Errorf("Expected collapsed error in group, got %s", out)
+		}
+		if strings.Contains(out, "user_id") {
+			t.Errorf("Expected no values, got %s", out)
+		}
+	})
+
+	t.Run("with attrs", func(t *testing.T) {
+		logger, buf := newLogger(nil)
+		err := goerr.New("failed")
+		goerr.MarkLogged(err)
+
+		logger.With("service", "api").WithGroup("g").Error("again", slog.Any("error", err))
+		if buf.Len() != 0 {
+			t.Errorf("Expected no output, got %s", buf.String())
+		}
+	})
+}
+
+func TestLogMarksLogged(t *testing.T) {
+	var buf bytes.Buffer
+	err := goerr.New("failed")
+	goerr.Log(context.Background(), slog.New(slog.NewTextHandler(&buf, nil)), "failed", err)
+	if !goerr.IsLogged(err) {
+		t.Error("Expected Log to mark the error")
+	}
+}
+
+func TestLogDisabledLevel(t *testing.T) {
+	var buf bytes.Buffer
+	warnLogger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelWarn}))
+	err := goerr.New("cache miss", goerr.Severity(goerr.SeverityDebug))
+
+	goerr.Log(context.Background(), warnLogger, "debug", err)
+	if buf.Len() != 0 {
+		t.Fatalf("Expected no output, got %s", buf.String())
+	}
+	if goerr.IsLogged(err) {
+		t.Error("Expected error not to be marked if it is not logged")
+	}
+
+	// The error wrapped by caller is still logged by LogHandler
+	logger := slog.New(goerr.NewLogHandler(slog.NewTextHandler(&buf, nil), nil))
+	logger.Error("request failed", slog.Any("error", goerr.Wrap(err, "failed")))
+	if !strings.Contains(buf.String(), "request failed") {
+		t.Errorf("Expected wrapped error to be logged, got %s", buf.String())
+	}
+}
@@ -0,0 +1,132 @@
+package goerr
+
+import (
+	"strings"
+	"sync/atomic"
+	"unicode/utf8"
+)
+
+// MessageMode selects which messages of a chain are composed by (*Error).Error.
+type MessageMode int
+
+const (
+	// MessageFull composes all messages from outermost to innermost. It is the default.
+	MessageFull MessageMode = iota
+	// MessageOutermost uses only the outermost message.
+	MessageOutermost
+	// MessageInnermost uses only the innermost message, i.e. message of the root cause.
+	MessageInnermost
+)
+
+// MessageFormat is a policy of composing the message returned by (*Error).Error. The zero value composes "msg: cause" in the same way as fmt.Errorf with %w.
+type MessageFormat struct {
+	// Mode selects messages to compose.
+	Mode MessageMode
+	// Separator is inserted between messages. Default is ": ".
+	Separator string
+	// Dedup removes a message that is the same as the previous one, e.g. "failed to get user: failed to get user: not found" becomes "failed to get user: not found".
+	Dedup bool
+	// MaxLength truncates the composed message to MaxLength characters with "..." at the end. 0 means no limit.
+	MaxLength int
+	// Ops prefixes the composed message with the operation path, e.g. "[api.Handle > UserService.Get] failed to get user: not found". See Op.
+	Ops bool
+}
+
+const messageEllipsis = "..."
+
+var messageFormat atomic.Pointer[MessageFormat]
+
+// SetMessageFormat sets the global message format used by (*Error).Error of errors without MsgFormat option. Format with %v, %s, %q and %+v, and (*Errors).Error use messages composed by the format.
+//
+// Usage:
+//
+//	goerr.SetMessageFormat(goerr.MessageFormat{Dedup: true, MaxLength: 256})
+func SetMessageFormat(f MessageFormat) {
+	messageFormat.Store(&f)
+}
+
+// CurrentMessageFormat returns the global message format set by SetMessageFormat.
+func CurrentMessageFormat() MessageFormat {
+	if f := messageFormat.Load(); f != nil {
+		return *f
+	}
+	return MessageFormat{}
+}
+
+// MsgFormat sets message format of the error instead of the global one. If errors in a chain have different formats, the format of the outermost error is used.
+//
+// Usage:
+//
+//	return goerr.Wrap(err, "failed to get user", goerr.MsgFormat(goerr.MessageFormat{Mode: goerr.MessageOutermost}))
+func MsgFormat(f MessageFormat) Option {
+	return func(err *Error) {
+		err.msgFormat = &f
+	}
+}
+
+// compose returns messages of x and its causes composed by f. Only *Error directly wrapped by Wrap or With is split into messages. Message of other cause, such as fmt.Errorf with %w, is used as a single message.
+func (f *MessageFormat) compose(x *Error) string {
+	var segments []string
+	add := func(msg string) {
+		if msg == "" {
+			return
+		}
+		if f.Dedup && len(segments) > 0 && segments[len(segments)-1] == msg {
+			return
+		}
+		segments = append(segments, msg)
+	}
+
+	for c := x; c != nil; {
+		add(c.msg)
+		if next, ok := c.cause.(*Error); ok {
+			c = next
+			continue
+		}
+		if c.cause != nil {
+			add(c.cause.Error())
+		}
+		break
+	}
+
+	switch {
+	case len(segments) == 0:
+	case f.Mode == MessageOutermost:
+		segments = segments[:1]
+	case f.Mode == MessageInnermost:
+		segments = segments[len(segments)-1:]
+	}
+
+	separator := f.Separator
+	if separator == "" {
+		separator = ": "
+	}
+	msg := strings.Join(segments, separator)
+
+	if f.Ops {
+		if ops := x.opsPath(); ops != "" {
+			msg = "[" + ops + "] " + msg
+		}
+	}
+
+	return truncateMessage(msg, f.MaxLength)
+}
+
+// truncateMessage truncates msg to maxLength characters including the ellipsis. It does not split a multi-byte character.
+func truncateMessage(msg string, maxLength int) string {
+	if maxLength <= 0 || utf8.RuneCountInString(msg) <= maxLength {
+		return msg
+	}
+
+	keep := maxLength - len(messageEllipsis)
+	if keep <= 0 {
+		return messageEllipsis[:maxLength]
+	}
+	for i := range msg {
+		if keep == 0 {
+			return msg[:i] + messageEllipsis
+		}
+		keep--
+	}
+	return msg
+}
@@ -0,0 +1,121 @@
+package goerr_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/m-mizutani/goerr/v2"
+)
+
+func setMessageFormat(t *testing.T, f goerr.MessageFormat) {
+	goerr.SetMessageFormat(f)
+	t.Cleanup(func() { goerr.SetMessageFormat(goerr.MessageFormat{}) })
+}
+
+func newDuplicatedError() *goerr.Error {
+	err := goerr.Wrap(errors.New("not found"), "failed to get user", goerr.Op("repo.Find"))
+	return goerr.Wrap(err, "failed to get user", goerr.Op("UserService.Get"))
+}
+
+func TestMessageFormat(t *testing.T) {
+	testCases := map[string]struct {
+		format goerr.MessageFormat
+		want   string
+	}{
+		"default": {
+			format: goerr.MessageFormat{},
+			want:   "failed to get user: failed to get user: not found",
+		},
+		"separator": {
+			format: goerr.MessageFormat{Separator: " <- "},
+			want:   "failed to get user <- failed to get user <- not found",
+		},
+		"dedup": {
+			format: goerr.MessageFormat{Dedup: true},
+			want:   "failed to get user: not found",
+		},
+		"outermost": {
+			format: goerr.MessageFormat{Mode: goerr.MessageOutermost},
+			want:   "failed to get user",
+		},
+		"innermost": {
+			format: goerr.MessageFormat{Mode: goerr.MessageInnermost},
+			want:   "not found",
+		},
+		"max length": {
+			format: goerr.MessageFormat{MaxLength: 20},
+			want:   "failed to get use...",
+		},
+		"ops": {
+			format: goerr.MessageFormat{Dedup: true, Ops: true},
+			want:   "[UserService.Get > repo.Find] failed to get user: not found",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			setMessageFormat(t, tc.format)
+			if got := newDuplicatedError().Error(); got != tc.want {
+				t.Errorf("Expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestMessageFormatTruncate(t *testing.T) {
+	setMessageFormat(t, goerr.MessageFormat{MaxLength: 5})
+	if got := goerr.New("ユーザーが見つかりません").Error(); got != "ユー..." {
+		t.Errorf("Expected truncation by characters, got %q", got)
+	}
+	if got := goerr.New("short").Error(); got != "short" {
+		t.Errorf("Expected no truncation, got %q", got)
+	}
+
+	setMessageFormat(t, goerr.MessageFormat{MaxLength: 2})
+	if got := goerr.New("failed").Error(); got != ".." {
+		t.Errorf("Expected only ellipsis, got %q", got)
+	}
+}
+
+func TestMsgFormatOption(t *testing.T) {
+	setMessageFormat(t, goerr.MessageFormat{Dedup: true})
+
+	inner := goerr.Wrap(errors.New("not found"), "inner", goerr.MsgFormat(goerr.MessageFormat{Mode: goerr.MessageInnermost}))
+	if got := inner.Error(); got != "not found" {
+		t.Errorf("Expected per-error format, got %q", got)
+	}
+
+	outer := goerr.Wrap(inner, "outer")
+	if got := outer.Error(); got != "outer: inner: not found" {
+		t.Errorf("Expected format of outermost error, got %q", got)
+	}
+
+	if got := goerr.With(inner, goerr.V("k", "v")).Error(); got != "not found" {
+		t.Errorf("Expected format to be copied by With, got %q", got)
+	}
+}
+
+func TestMessageFormatConsistency(t *testing.T) {
+	setMessageFormat(t, goerr.MessageFormat{Dedup: true})
+	err := newDuplicatedError()
+	want := "failed to get user: not found"
+
+	for _, verb := range []string{"%v", "%s"} {
+		if got := fmt.Sprintf(verb, err); got != want {
+			t.Errorf("Expected %q for %s, got %q", want, verb, got)
+		}
+	}
+	if got := fmt.Sprintf("%q", err); got != fmt.Sprintf("%q", want) {
+		t.Errorf("Expected quoted message, got %s", got)
+	}
+	if got := fmt.Sprintf("%+v", err); !strings.HasPrefix(got, want+"\n") {
+		t.Errorf("Expected %q at the beginning, got %s", want, got)
+	}
+
+	errs := goerr.Join(err, goerr.New("other"))
+	if got := errs.Error(); got != want+"\nother" {
+		t.Errorf("Expected formatted messages in errors, got %q", got)
+	}
+}
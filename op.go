@@ -0,0 +1,52 @@
+package goerr
+
+import "strings"
+
+// OpsSeparator is the separator of operations in the path formatted by %+v, LogValue and `goerr pretty`.
+const OpsSeparator = " > "
+
+// Op sets the logical operation that failed, such as "UserService.Get". Operations of the chain compose a readable path even if stack traces are trimmed or omitted.
+//
+// Usage:
+//
+//	func (s *UserService) Get(ctx context.Context, id string) (*User, error) {
+//		user, err := s.repo.Find(ctx, id)
+//		if err != nil {
+//			return nil, goerr.Wrap(err, "failed to get user", goerr.Op("UserService.Get"))
+//		}
+//		...
+//	}
+func Op(op string) Option {
+	return func(err *Error) {
+		err.op = op
+	}
+}
+
+// Op returns the operation set by Op option. It returns empty string if not set.
+func (x *Error) Op() string {
+	return x.op
+}
+
+// Ops returns operations of x and its wrapped errors from outermost to innermost. Errors without operation are skipped.
+func (x *Error) Ops() []string {
+	var ops []string
+	for _, e := range x.chain() {
+		if e.op != "" {
+			ops = append(ops, e.op)
+		}
+	}
+	return ops
+}
+
+// Ops returns operations in err from outermost to innermost, e.g. ["api.Handle", "UserService.Get", "repo.Find"]. It returns nil if err is not *Error or has no operation.
+func Ops(err error) []string {
+	if e := Unwrap(err); e != nil {
+		return e.Ops()
+	}
+	return nil
+}
+
+// opsPath returns operations of x joined by OpsSeparator.
+func (x *Error) opsPath() string {
+	return strings.Join(x.Ops(), OpsSeparator)
+}
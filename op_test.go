@@ -0,0 +1,77 @@
+package goerr_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/m-mizutani/goerr/v2"
+)
+
+func newOpsError() *goerr.Error {
+	err := goerr.New("not found", goerr.Op("repo.Find"))
+	err = goerr.Wrap(err, "no op")
+	err = goerr.Wrap(err, "failed to get user", goerr.Op("UserService.Get"))
+	return goerr.Wrap(fmt.Errorf("handler: %w", err), "failed to handle", goerr.Op("api.Handle"))
+}
+
+func TestOps(t *testing.T) {
+	want := []string{"api.Handle", "UserService.Get", "repo.Find"}
+	if got := goerr.Ops(newOpsError()); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+
+	if got := goerr.Ops(goerr.New("failed")); got != nil {
+		t.Errorf("Expected nil, got %v", got)
+	}
+	if got := goerr.Ops(errors.New("failed")); got != nil {
+		t.Errorf("Expected nil, got %v", got)
+	}
+
+	if got := goerr.With(goerr.New("failed", goerr.Op("a")), goerr.Op("b")).Op(); got != "b" {
+		t.Errorf("Expected op overridden by With, got %q", got)
+	}
+}
+
+func TestOpsOutput(t *testing.T) {
+	err := newOpsError()
+
+	t.Run("format", func(t *testing.T) {
+		out := fmt.Sprintf("%+v", err)
+		if !strings.Contains(out, "\nOps:\n  api.Handle > UserService.Get > repo.Find\n") {
+			t.Errorf("Expected ops in output, got %s", out)
+		}
+		if out := fmt.Sprintf("%+v", goerr.New("failed")); strings.Contains(out, "Ops:") {
+			t.Errorf("Expected no ops section, got %s", out)
+		}
+	})
+
+	t.Run("printable", func(t *testing.T) {
+		p := err.Printable()
+		if want := []string{"api.Handle", "UserService.Get", "repo.Find"}; !reflect.DeepEqual(p.Ops, want) {
+			t.Errorf("Expected %v, got %v", want, p.Ops)
+		}
+	})
+
+	t.Run("log value", func(t *testing.T) {
+		var buf bytes.Buffer
+		slog.New(slog.NewJSONHandler(&buf, nil)).Error("failed", slog.Any("error", err))
+
+		var out struct {
+			Error struct {
+				Ops []string `json:"ops"`
+			} `json:"error"`
+		}
+		if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+			t.Fatal(err)
+		}
+		if want := []string{"api.Handle", "UserService.Get", "repo.Find"}; !reflect.DeepEqual(out.Error.Ops, want) {
+			t.Errorf("Expected %v, got %v", want, out.Error.Ops)
+		}
+	})
+}
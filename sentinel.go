@@ -0,0 +1,45 @@
+package goerr
+
+// Sentinel creates an immutable sentinel error with ID and message. Unlike New, it does not capture stack trace because a stack trace of package initialization is meaningless.
+//
+// A sentinel error is never modified. WithTags, Unstack and UnstackN return a modified copy instead of changing the sentinel error. (*Error).Wrap, goerr.Wrap and goerr.With create a fresh *Error with stack trace of the call site, and the result still matches the sentinel error with errors.Is.
+//
+// Usage:
+//
+//	var ErrUserNotFound = goerr.Sentinel("user_not_found", "user not found", goerr.T(TagNotFound))
+//
+//	func FindUser(id string) (*User, error) {
+//		...
+//		return nil, ErrUserNotFound.Wrap(sql.ErrNoRows, goerr.V("user_id", id))
+//	}
+//
+//	if errors.Is(err, ErrUserNotFound) { ... }
+func Sentinel(id, msg string, options ...Option) *Error {
+	err := &Error{
+		msg:         msg,
+		id:          id,
+		values:      make(values),
+		typedValues: make(map[string]any),
+		tags:        make(tags),
+		sentinel:    true,
+	}
+
+	for _, opt := range options {
+		opt(err)
+	}
+
+	return err
+}
+
+// IsSentinel returns true if the error is created by Sentinel. Errors derived from a sentinel error by Wrap or With are not sentinel errors.
+func (x *Error) IsSentinel() bool {
+	return x.sentinel
+}
+
+// clone returns a modifiable copy of the sentinel error. It must be called directly from an exported method of Error because the frame of the method is trimmed from stack trace of the copy.
+func (x *Error) clone() *Error {
+	err := newError()
+	x.copy(err)
+	err.st = unstack(err.st, 1)
+	return err
+}
@@ -0,0 +1,93 @@
+package goerr_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/m-mizutani/goerr/v2"
+)
+
+var (
+	tagSentinel       = goerr.NewTag("sentinel")
+	errSentinelTarget = goerr.Sentinel("not_found", "not found", goerr.T(tagSentinel), goerr.V("kind", "resource"))
+)
+
+func TestSentinel(t *testing.T) {
+	if !errSentinelTarget.IsSentinel() {
+		t.Error("Expected sentinel error")
+	}
+	if errSentinelTarget.Stacks() != nil || errSentinelTarget.StackTrace() != nil {
+		t.Error("Sentinel error should not have stack trace")
+	}
+	if errSentinelTarget.Error() != "not found" {
+		t.Errorf("Unexpected message: %s", errSentinelTarget.Error())
+	}
+	if !errSentinelTarget.HasTag(tagSentinel) || errSentinelTarget.Values()["kind"] != "resource" {
+		t.Error("Sentinel error should have options")
+	}
+
+	// %+v of sentinel error itself does not panic
+	if !strings.Contains(fmt.Sprintf("%+v", errSentinelTarget), "not found") {
+		t.Error("Unexpected format")
+	}
+}
+
+func TestSentinelWrap(t *testing.T) {
+	cause := errors.New("no rows")
+	err := errSentinelTarget.Wrap(cause, goerr.V("user_id", "u1"))
+
+	if err.IsSentinel() {
+		t.Error("Wrapped error should not be sentinel")
+	}
+	if !errors.Is(err, errSentinelTarget) || !errors.Is(err, cause) {
+		t.Error("Wrapped error should match sentinel and cause")
+	}
+	stacks := err.Stacks()
+	if len(stacks) == 0 || !strings.HasSuffix(stacks[0].Func, "TestSentinelWrap") {
+		t.Errorf("Expected stack trace of call site: %v", stacks)
+	}
+	if v := err.Values(); v["kind"] != "resource" || v["user_id"] != "u1" {
+		t.Errorf("Unexpected values: %v", v)
+	}
+
+	wrapped := goerr.Wrap(errSentinelTarget, "failed")
+	if !errors.Is(wrapped, errSentinelTarget) {
+		t.Error("Expected to match sentinel")
+	}
+	if !strings.Contains(fmt.Sprintf("%+v", wrapped), "TestSentinelWrap") {
+		t.Error("Expected stack trace of call site in detailed format")
+	}
+
+	with := goerr.With(errSentinelTarget, goerr.V("extra", 1))
+	if with.IsSentinel() || !errors.Is(with, errSentinelTarget) {
+		t.Error("With should create non-sentinel error that matches sentinel")
+	}
+	if stacks := with.Stacks(); len(stacks) == 0 || !strings.HasSuffix(stacks[0].Func, "TestSentinelWrap") {
+		t.Errorf("Expected stack trace of call site: %v", stacks)
+	}
+}
+
+func TestSentinelImmutable(t *testing.T) {
+	sentinel := goerr.Sentinel("immutable", "immutable")
+	tag := goerr.NewTag("added")
+
+	tagged := sentinel.WithTags(tag)
+	if tagged == sentinel || sentinel.HasTag(tag) {
+		t.Error("WithTags should not modify sentinel error")
+	}
+	if !tagged.HasTag(tag) || !errors.Is(tagged, sentinel) {
+		t.Error("WithTags should return tagged copy")
+	}
+	if stacks := tagged.Stacks(); len(stacks) == 0 || !strings.HasSuffix(stacks[0].Func, "TestSentinelImmutable") {
+		t.Errorf("Expected stack trace of call site: %v", stacks)
+	}
+
+	if sentinel.Unstack() == sentinel || sentinel.UnstackN(2) == sentinel {
+		t.Error("Unstack should not modify sentinel error")
+	}
+	if sentinel.Stacks() != nil {
+		t.Error("Sentinel error should not have stack trace")
+	}
+}
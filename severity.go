@@ -0,0 +1,168 @@
+package goerr
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+)
+
+// SeverityLevel represents how serious an error is. The zero value SeverityUnspecified means that severity is not set.
+type SeverityLevel int
+
+const (
+	SeverityUnspecified SeverityLevel = iota
+	SeverityDebug
+	SeverityInfo
+	SeverityWarn
+	SeverityError
+	SeverityCritical
+)
+
+// String returns name of the severity, e.g. "warn". It returns empty string for SeverityUnspecified.
+func (x SeverityLevel) String() string {
+	switch x {
+	case SeverityDebug:
+		return "debug"
+	case SeverityInfo:
+		return "info"
+	case SeverityWarn:
+		return "warn"
+	case SeverityError:
+		return "error"
+	case SeverityCritical:
+		return "critical"
+	}
+	return ""
+}
+
+// Level returns slog.Level of the severity. SeverityCritical is mapped to slog.LevelError+4, and SeverityUnspecified is mapped to slog.LevelError.
+func (x SeverityLevel) Level() slog.Level {
+	switch x {
+	case SeverityDebug:
+		return slog.LevelDebug
+	case SeverityInfo:
+		return slog.LevelInfo
+	case SeverityWarn:
+		return slog.LevelWarn
+	case SeverityCritical:
+		return slog.LevelError + 4
+	}
+	return slog.LevelError
+}
+
+// Severity sets severity to the error. If errors in a chain have different severities, the highest one is used.
+//
+// Usage:
+//
+//	return goerr.Wrap(err, "cache miss", goerr.Severity(goerr.SeverityWarn))
+func Severity(level SeverityLevel) Option {
+	return func(err *Error) {
+		err.severity = level
+	}
+}
+
+// Severity returns the highest severity in x and its wrapped errors. It returns SeverityUnspecified if no severity is set.
+func (x *Error) Severity() SeverityLevel {
+	highest := SeverityUnspecified
+	for _, e := range x.chain() {
+		highest = max(highest, e.severity)
+	}
+	return highest
+}
+
+// Severity returns the highest severity of all errors. It returns SeverityUnspecified if no severity is set.
+func (x *Errors) Severity() SeverityLevel {
+	highest := SeverityUnspecified
+	if x == nil {
+		return highest
+	}
+	for _, err := range x.errs {
+		highest = max(highest, GetSeverity(err))
+	}
+	return highest
+}
+
+// GetSeverity returns the highest severity in err. err can be *Error, *Errors or an error wrapping them, including *Error wrapping *Errors.
+func GetSeverity(err error) SeverityLevel {
+	levels, errs := outerLevels(err)
+	highest := errs.Severity()
+	for _, e := range levels {
+		highest = max(highest, e.severity)
+	}
+	return highest
+}
+
+var tagSeverities = struct {
+	mu     sync.RWMutex
+	levels map[string]SeverityLevel
+}{
+	levels: make(map[string]SeverityLevel),
+}
+
+// SetTagSeverity sets severity implied by the tag. It is used by Log when resolving slog.Level of an error.
+//
+// Usage:
+//
+//	goerr.SetTagSeverity(TagNotFound, goerr.SeverityInfo)
+func SetTagSeverity(t tag, level SeverityLevel) {
+	tagSeverities.mu.Lock()
+	defer tagSeverities.mu.Unlock()
+	tagSeverities.levels[t.value] = level
+}
+
+// LogLevel returns slog.Level for err. It is the highest of severities set by Severity option and severities of tags set by SetTagSeverity. If neither is set, slog.LevelInfo is returned for an expected error (see Expected) and slog.LevelError is returned otherwise.
+func LogLevel(err error) slog.Level {
+	highest := GetSeverity(err)
+
+	tagSeverities.mu.RLock()
+	for _, name := range allTags(err) {
+		highest = max(highest, tagSeverities.levels[name])
+	}
+	tagSeverities.mu.RUnlock()
+
+	if highest == SeverityUnspecified && IsExpected(err) {
+		return slog.LevelInfo
+	}
+
+	return highest.Level()
+}
+
+// allTags returns tag names of err, including tags of *Error wrapping *Errors and tags of all errors in *Errors.
+func allTags(err error) []string {
+	levels, errs := outerLevels(err)
+
+	var names []string
+	for _, e := range levels {
+		for t := range e.tags {
+			names = append(names, t.value)
+		}
+	}
+	if errs != nil {
+		for _, e := range errs.errs {
+			names = append(names, allTags(e)...)
+		}
+	}
+	return names
+}
+
+// Log logs err with logger at the level resolved by LogLevel and marks err by MarkLogged. The error is logged as "error" attribute. If logger is nil, slog.Default() is used. If err is nil or the level is not enabled by logger, nothing is logged and err is not marked.
+//
+// Usage:
+//
+//	if err := handle(r); err != nil {
+//		goerr.Log(r.Context(), logger, "failed to handle request", err)
+//	}
+func Log(ctx context.Context, logger *slog.Logger, msg string, err error) {
+	if err == nil {
+		return
+	}
+	if logger == nil {
+		logger = slog.Default()
+	}
+	level := LogLevel(err)
+	if !logger.Enabled(ctx, level) {
+		return
+	}
+	logger.Log(ctx, level, msg, slog.Any("error", err))
+	MarkLogged(err)
+}
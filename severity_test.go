@@ -0,0 +1,200 @@
+package goerr_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"github.com/m-mizutani/goerr/v2"
+)
+
+func TestSeverity(t *testing.T) {
+	testCases := map[string]struct {
+		err  error
+		want goerr.SeverityLevel
+	}{
+		"not set": {
+			err:  goerr.New("failed"),
+			want: goerr.SeverityUnspecified,
+		},
+		"set": {
+			err:  goerr.New("failed", goerr.Severity(goerr.SeverityWarn)),
+			want: goerr.SeverityWarn,
+		},
+		"highest in chain": {
+			err: goerr.Wrap(
+				goerr.New("failed", goerr.Severity(goerr.SeverityCritical)),
+				"wrapped", goerr.Severity(goerr.SeverityInfo)),
+			want: goerr.SeverityCritical,
+		},
+		"through fmt.Errorf": {
+			err:  fmt.Errorf("outer: %w", goerr.New("failed", goerr.Severity(goerr.SeverityDebug))),
+			want: goerr.SeverityDebug,
+		},
+		"errors": {
+			err: goerr.Join(
+				goerr.New("a", goerr.Severity(goerr.SeverityWarn)),
+				goerr.New("b", goerr.Severity(goerr.SeverityError)),
+			),
+			want: goerr.SeverityError,
+		},
+		"standard error": {
+			err:  fmt.Errorf("failed"),
+			want: goerr.SeverityUnspecified,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if got := goerr.GetSeverity(tc.err); got != tc.want {
+				t.Errorf("Expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestSeverityLevel(t *testing.T) {
+	testCases := []struct {
+		severity goerr.SeverityLevel
+		name     string
+		level    slog.Level
+	}{
+		{goerr.SeverityUnspecified, "", slog.LevelError},
+		{goerr.SeverityDebug, "debug", slog.LevelDebug},
+		{goerr.SeverityInfo, "info", slog.LevelInfo},
+		{goerr.SeverityWarn, "warn", slog.LevelWarn},
+		{goerr.SeverityError, "error", slog.LevelError},
+		{goerr.SeverityCritical, "critical", slog.LevelError + 4},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.severity.String(); got != tc.name {
+			t.Errorf("Expected name %q, got %q", tc.name, got)
+		}
+		if got := tc.severity.Level(); got != tc.level {
+			t.Errorf("Expected level %v for %q, got %v", tc.level, tc.name, got)
+		}
+	}
+}
+
+func TestLogLevel(t *testing.T) {
+	tagNotFound := goerr.NewTag("severity_test_not_found")
+	tagFatal := goerr.NewTag("severity_test_fatal")
+	goerr.SetTagSeverity(tagNotFound, goerr.SeverityInfo)
+	goerr.SetTagSeverity(tagFatal, goerr.SeverityCritical)
+	t.Cleanup(func() {
+		goerr.SetTagSeverity(tagNotFound, goerr.SeverityUnspecified)
+		goerr.SetTagSeverity(tagFatal, goerr.SeverityUnspecified)
+	})
+
+	testCases := map[string]struct {
+		err  error
+		want slog.Level
+	}{
+		"default": {
+			err:  goerr.New("failed"),
+			want: slog.LevelError,
+		},
+		"severity": {
+			err:  goerr.New("failed", goerr.Severity(goerr.SeverityWarn)),
+			want: slog.LevelWarn,
+		},
+		"tag": {
+			err:  goerr.New("failed", goerr.T(tagNotFound)),
+			want: slog.LevelInfo,
+		},
+		"higher of tag and severity": {
+			err:  goerr.New("failed", goerr.T(tagFatal), goerr.Severity(goerr.SeverityWarn)),
+			want: slog.LevelError + 4,
+		},
+		"tag in errors": {
+			err:  goerr.Join(goerr.New("a"), goerr.New("b", goerr.T(tagNotFound))),
+			want: slog.LevelInfo,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if got := goerr.LogLevel(tc.err); got != tc.want {
+				t.Errorf("Expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestLog(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	goerr.Log(context.Background(), logger, "cache miss",
+		goerr.New("not cached", goerr.Severity(goerr.SeverityWarn)))
+
+	var out struct {
+		Level string `json:"level"`
+		Msg   string `json:"msg"`
+		Error struct {
+			Message  string `json:"message"`
+			Severity string `json:"severity"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.Level != "WARN" {
+		t.Errorf("Expected WARN, got %q", out.Level)
+	}
+	if out.Msg != "cache miss" || out.Error.Message != "not cached" {
+		t.Errorf("Unexpected log: %s", buf.String())
+	}
+	if out.Error.Severity != "warn" {
+		t.Errorf("Expected severity warn, got %q", out.Error.Severity)
+	}
+
+	buf.Reset()
+	goerr.Log(context.Background(), logger, "no error", nil)
+	if buf.Len() != 0 {
+		t.Errorf("Expected no log for nil error, got %s", buf.String())
+	}
+}
+
+func TestSeverityPrintable(t *testing.T) {
+	err := goerr.Wrap(goerr.New("inner", goerr.Severity(goerr.SeverityCritical)), "outer")
+	p := err.Printable()
+	if p.Severity != "critical" {
+		t.Errorf("Expected critical, got %q", p.Severity)
+	}
+	if p.Cause.(*goerr.Printable).Severity != "critical" {
+		t.Errorf("Expected critical in cause, got %q", p.Cause.(*goerr.Printable).Severity)
+	}
+}
+
+func TestSeverityWrappingErrors(t *testing.T) {
+	tagFatal := goerr.NewTag("severity_test_wrapping_fatal")
+	goerr.SetTagSeverity(tagFatal, goerr.SeverityCritical)
+	t.Cleanup(func() { goerr.SetTagSeverity(tagFatal, goerr.SeverityUnspecified) })
+
+	warnErr := goerr.New("warn", goerr.Severity(goerr.SeverityWarn))
+	errs := goerr.Join(warnErr, goerr.New("other"))
+
+	outer := goerr.Wrap(errs, "batch", goerr.Severity(goerr.SeverityCritical))
+	if got := goerr.GetSeverity(outer); got != goerr.SeverityCritical {
+		t.Errorf("Expected severity of outer error, got %q", got)
+	}
+	if got := goerr.LogLevel(outer); got != slog.LevelError+4 {
+		t.Errorf("Expected level of outer error, got %v", got)
+	}
+
+	tagged := goerr.Wrap(errs, "batch", goerr.T(tagFatal))
+	if got := goerr.LogLevel(tagged); got != slog.LevelError+4 {
+		t.Errorf("Expected level of outer tag, got %v", got)
+	}
+
+	// Severity of errors in *Errors is still used if it is higher
+	lower := goerr.Wrap(errs, "batch", goerr.Severity(goerr.SeverityDebug))
+	if got := goerr.GetSeverity(fmt.Errorf("wrapped: %w", lower)); got != goerr.SeverityWarn {
+		t.Errorf("Expected severity of inner errors, got %q", got)
+	}
+}
@@ -4,9 +4,11 @@ import (
 	"fmt"
 	"io"
 	"path"
+	"reflect"
 	"runtime"
 	"strconv"
 	"strings"
+	"sync"
 )
 
 // Stack represents function, file and line No of stack trace
@@ -16,20 +18,17 @@ type Stack struct {
 	Line int    `json:"line"`
 }
 
-// Stacks returns stack trace array generated by pkg/errors
+// Stacks returns stack trace array generated by pkg/errors. Inlined function calls are expanded into separate frames.
 func (x *Error) Stacks() []*Stack {
 	if x.st == nil {
 		return nil
 	}
 
-	stacks := make([]*Stack, 0, len(*x.st))
-	for _, pc := range *x.st {
-		f := newFrame(pc)
-		stacks = append(stacks, &Stack{
-			Func: f.getFunctionName(),
-			File: f.getFilePath(),
-			Line: f.getLineNumber(),
-		})
+	frames := x.st.frames()
+	stacks := make([]*Stack, len(frames))
+	for i := range frames {
+		st := frames[i]
+		stacks[i] = &st
 	}
 	return stacks
 }
@@ -42,6 +41,67 @@ func (x *Error) StackTrace() StackTrace {
 	return x.st.toStackTrace()
 }
 
+// Callers returns program counters of the stack trace as runtime.Callers returns. It is compatible with errors that expose raw stack, such as github.com/go-errors/errors, and can be resolved by runtime.CallersFrames.
+func (x *Error) Callers() []uintptr {
+	if x.st == nil {
+		return nil
+	}
+	pcs := make([]uintptr, len(*x.st))
+	copy(pcs, *x.st)
+	return pcs
+}
+
+// adoptStack replaces stack trace of x with the stack trace of the cause if the cause is a third-party error that has its own stack trace. It keeps the origin of the error in Format, Stacks() and Printable. The stack trace is searched in the chain of single Unwrap until *Error.
+func (x *Error) adoptStack() {
+	w := newWalker()
+	for err := x.cause; err != nil && w.enter(err); {
+		if _, ok := err.(*Error); ok {
+			return
+		}
+		if st := foreignStack(err); st != nil {
+			x.st = st
+			return
+		}
+
+		u, ok := err.(interface{ Unwrap() error })
+		if !ok {
+			return
+		}
+		err = u.Unwrap()
+	}
+}
+
+// foreignStack returns stack trace of err exposed by Callers() []uintptr, or by StackTrace() of github.com/pkg/errors. StackTrace is detected by reflection to avoid dependency on pkg/errors, as a method returning a slice of uintptr based type whose value is program counter + 1.
+func foreignStack(err error) *stack {
+	if c, ok := err.(interface{ Callers() []uintptr }); ok {
+		if pcs := c.Callers(); len(pcs) > 0 {
+			st := make(stack, len(pcs))
+			copy(st, pcs)
+			return &st
+		}
+		return nil
+	}
+
+	method := reflect.ValueOf(err).MethodByName("StackTrace")
+	if !method.IsValid() {
+		return nil
+	}
+	t := method.Type()
+	if t.NumIn() != 0 || t.NumOut() != 1 || t.Out(0).Kind() != reflect.Slice || t.Out(0).Elem().Kind() != reflect.Uintptr {
+		return nil
+	}
+
+	frames := method.Call(nil)[0]
+	if frames.Len() == 0 {
+		return nil
+	}
+	st := make(stack, frames.Len())
+	for i := range st {
+		st[i] = uintptr(frames.Index(i).Uint())
+	}
+	return &st
+}
+
 // frame represents a single stack frame
 type frame uintptr
 
@@ -61,33 +121,25 @@ func (f frame) pc() uintptr {
 	return uintptr(f)
 }
 
+// resolve returns the innermost frame at the program counter. If the call is inlined, it is the inlined function.
+func (f frame) resolve() Stack {
+	// resolvePC receives a return address as runtime.Callers returns
+	return resolvePC(f.pc() + 1)[0]
+}
+
 // getFilePath returns the full path to the file that contains the function
 func (f frame) getFilePath() string {
-	fn := runtime.FuncForPC(f.pc())
-	if fn == nil {
-		return "unknown"
-	}
-	file, _ := fn.FileLine(f.pc())
-	return file
+	return f.resolve().File
 }
 
 // getLineNumber returns the line number of source code
 func (f frame) getLineNumber() int {
-	fn := runtime.FuncForPC(f.pc())
-	if fn == nil {
-		return 0
-	}
-	_, line := fn.FileLine(f.pc())
-	return line
+	return f.resolve().Line
 }
 
 // getFunctionName returns the name of this function
 func (f frame) getFunctionName() string {
-	fn := runtime.FuncForPC(f.pc())
-	if fn == nil {
-		return "unknown"
-	}
-	return fn.Name()
+	return f.resolve().Func
 }
 
 // Format implements fmt.Formatter interface
@@ -182,13 +234,52 @@ func (st StackTrace) formatSlice(s fmt.State, verb rune) {
 // Format implements fmt.Formatter interface for stack
 func (s *stack) Format(st fmt.State, verb rune) {
 	if verb == 'v' && st.Flag('+') {
-		for _, pc := range *s {
-			f := newFrame(pc)
-			fmt.Fprintf(st, "\n%+v", f)
+		for _, f := range s.frames() {
+			fmt.Fprintf(st, "\n%s\n\t%s:%d", f.Func, f.File, f.Line)
 		}
 	}
 }
 
+// frames resolves program counters of the stack into frames. Inlined function calls are expanded into separate frames.
+func (s *stack) frames() []Stack {
+	frames := make([]Stack, 0, len(*s))
+	for _, pc := range *s {
+		frames = append(frames, resolvePC(pc)...)
+	}
+	return frames
+}
+
+// symbolCache is resolved frames keyed by program counter. Its size is bounded by number of call sites that create errors.
+var symbolCache sync.Map // map[uintptr][]Stack
+
+// resolvePC returns frames at the return address pc. It returns multiple frames if the call is inlined, from innermost to outermost. The result must not be modified because it is shared by the cache.
+func resolvePC(pc uintptr) []Stack {
+	if cached, ok := symbolCache.Load(pc); ok {
+		return cached.([]Stack)
+	}
+
+	var resolved []Stack
+	frames := runtime.CallersFrames([]uintptr{pc})
+	for {
+		f, more := frames.Next()
+		st := Stack{Func: f.Function, File: f.File, Line: f.Line}
+		if st.Func == "" {
+			st.Func = "unknown"
+		}
+		if st.File == "" {
+			st.File = "unknown"
+		}
+		resolved = append(resolved, st)
+
+		if !more {
+			break
+		}
+	}
+
+	symbolCache.Store(pc, resolved)
+	return resolved
+}
+
 // toStackTrace converts stack to StackTrace
 func (s *stack) toStackTrace() StackTrace {
 	if s == nil {
@@ -0,0 +1,214 @@
+package goerr_test
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/m-mizutani/goerr/v2"
+)
+
+// newInlinedError is small enough to be inlined into callInlined
+func newInlinedError() *goerr.Error {
+	return goerr.New("inlined")
+}
+
+//go:noinline
+func callInlined() *goerr.Error {
+	return newInlinedError()
+}
+
+func TestStacksInlined(t *testing.T) {
+	err := callInlined()
+
+	stacks := err.Stacks()
+	if len(stacks) < 2 {
+		t.Fatalf("Unexpected stacks: %v", stacks)
+	}
+	if !strings.HasSuffix(stacks[0].Func, ".newInlinedError") || !strings.HasSuffix(stacks[1].Func, ".callInlined") {
+		t.Errorf("Inlined frame is not expanded: %s, %s", stacks[0].Func, stacks[1].Func)
+	}
+	if !strings.HasSuffix(stacks[0].File, "stack_test.go") || stacks[0].Line != 16 || stacks[1].Line != 21 {
+		t.Errorf("Unexpected location: %s:%d, %d", stacks[0].File, stacks[0].Line, stacks[1].Line)
+	}
+
+	// Format and LogValue have the same frames
+	formatted := fmt.Sprintf("%+v", err)
+	if !strings.Contains(formatted, "newInlinedError\n\t") || !strings.Contains(formatted, "callInlined\n\t") {
+		t.Errorf("Unexpected format:\n%s", formatted)
+	}
+	var buf bytes.Buffer
+	slog.New(slog.NewJSONHandler(&buf, nil)).Error("failed", "error", err)
+	if !strings.Contains(buf.String(), "stack_test.go:16 github.com/m-mizutani/goerr/v2_test.newInlinedError") {
+		t.Errorf("Unexpected log: %s", buf.String())
+	}
+
+	// Resolved frames are not shared between calls
+	stacks[0].Func = "modified"
+	if err.Stacks()[0].Func == "modified" {
+		t.Error("Stacks must return a copy")
+	}
+}
+
+func BenchmarkStacks(b *testing.B) {
+	err := callInlined()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = err.Stacks()
+	}
+}
+
+// BenchmarkStacksFuncForPC is a baseline that resolves each frame by runtime.FuncForPC three times as goerr did before.
+func BenchmarkStacksFuncForPC(b *testing.B) {
+	err := callInlined()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var stacks []*goerr.Stack
+		for _, f := range err.StackTrace() {
+			pc := uintptr(f)
+			st := &goerr.Stack{Func: "unknown", File: "unknown"}
+			if fn := runtime.FuncForPC(pc); fn != nil {
+				st.Func = fn.Name()
+			}
+			if fn := runtime.FuncForPC(pc); fn != nil {
+				st.File, _ = fn.FileLine(pc)
+			}
+			if fn := runtime.FuncForPC(pc); fn != nil {
+				_, st.Line = fn.FileLine(pc)
+			}
+			stacks = append(stacks, st)
+		}
+		_ = stacks
+	}
+}
+
+func BenchmarkLogValue(b *testing.B) {
+	err := goerr.Wrap(callInlined(), "wrapped", goerr.V("key", "value"))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = err.LogValue()
+	}
+}
+
+// pkgFrame and pkgStackTrace mimic github.com/pkg/errors. Frame is program counter + 1.
+type pkgFrame uintptr
+
+type pkgStackTrace []pkgFrame
+
+type pkgError struct {
+	msg string
+	pcs []uintptr
+}
+
+func (x *pkgError) Error() string { return x.msg }
+
+func (x *pkgError) StackTrace() pkgStackTrace {
+	frames := make(pkgStackTrace, len(x.pcs))
+	for i, pc := range x.pcs {
+		frames[i] = pkgFrame(pc)
+	}
+	return frames
+}
+
+// callersError mimics github.com/go-errors/errors
+type callersError struct {
+	pcs []uintptr
+}
+
+func (x *callersError) Error() string      { return "callers error" }
+func (x *callersError) Callers() []uintptr { return x.pcs }
+
+func capturePCs() []uintptr {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(2, pcs)
+	return pcs[:n]
+}
+
+//go:noinline
+func newPkgError() error {
+	return &pkgError{msg: "pkg error", pcs: capturePCs()}
+}
+
+//go:noinline
+func newCallersError() error {
+	return &callersError{pcs: capturePCs()}
+}
+
+func TestAdoptStack(t *testing.T) {
+	testCases := map[string]struct {
+		err    func() *goerr.Error
+		origin string
+	}{
+		"Wrap pkg/errors": {
+			err:    func() *goerr.Error { return goerr.Wrap(newPkgError(), "wrapped") },
+			origin: "newPkgError",
+		},
+		"Wrap Callers": {
+			err:    func() *goerr.Error { return goerr.Wrap(newCallersError(), "wrapped") },
+			origin: "newCallersError",
+		},
+		"With": {
+			err:    func() *goerr.Error { return goerr.With(newPkgError(), goerr.V("k", "v")) },
+			origin: "newPkgError",
+		},
+		"Wrap through fmt.Errorf": {
+			err:    func() *goerr.Error { return goerr.Wrap(fmt.Errorf("context: %w", newPkgError()), "wrapped") },
+			origin: "newPkgError",
+		},
+		"Unstack does not trim adopted stack": {
+			err:    func() *goerr.Error { return goerr.Wrap(newPkgError(), "wrapped", goerr.Unstack(1)) },
+			origin: "newPkgError",
+		},
+		"Error.Wrap": {
+			err:    func() *goerr.Error { return goerr.New("base").Wrap(newCallersError()) },
+			origin: "newCallersError",
+		},
+		"Builder": {
+			err:    func() *goerr.Error { return goerr.NewBuilder().Wrap(newPkgError(), "wrapped") },
+			origin: "newPkgError",
+		},
+		"standard error": {
+			err:    func() *goerr.Error { return goerr.Wrap(fmt.Errorf("plain"), "wrapped") },
+			origin: "TestAdoptStack.func",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.err()
+
+			stacks := err.Stacks()
+			if len(stacks) == 0 || !strings.Contains(stacks[0].Func, tc.origin) {
+				t.Fatalf("Expected origin %s, got %v", tc.origin, stacks)
+			}
+			if p := err.Printable(); !strings.Contains(p.StackTrace[0].Func, tc.origin) {
+				t.Errorf("Expected origin %s in Printable, got %s", tc.origin, p.StackTrace[0].Func)
+			}
+			if formatted := fmt.Sprintf("%+v", err); !strings.Contains(formatted, "\n"+stacks[0].Func+"\n") {
+				t.Errorf("Expected origin %s in format, got:\n%s", tc.origin, formatted)
+			}
+		})
+	}
+}
+
+func TestCallers(t *testing.T) {
+	err := goerr.New("error")
+
+	frame, _ := runtime.CallersFrames(err.Callers()).Next()
+	if frame.Function != "github.com/m-mizutani/goerr/v2_test.TestCallers" {
+		t.Errorf("Unexpected function: %s", frame.Function)
+	}
+
+	// goerr error can be adopted by another goerr error through an interface of other libraries
+	adopted := goerr.Wrap(&callersError{pcs: err.Callers()}, "wrapped")
+	if adopted.Stacks()[0].Func != "github.com/m-mizutani/goerr/v2_test.TestCallers" {
+		t.Errorf("Unexpected origin: %s", adopted.Stacks()[0].Func)
+	}
+
+	if goerr.Sentinel("callers", "no stack").Callers() != nil {
+		t.Error("Sentinel error should not have callers")
+	}
+}
@@ -0,0 +1,58 @@
+package goerr
+
+import (
+	"os"
+	"reflect"
+	"strconv"
+	"sync"
+	"sync/atomic"
+
+	"github.com/m-mizutani/goerr/v2/internal/buildid"
+)
+
+var deferredSymbolization atomic.Bool
+
+// SetDeferredSymbolization enables or disables deferred symbolization. If enabled, Printable and LogValue emit raw program counters with the build ID of the running binary as RawStack instead of resolving function names, files and lines by runtime.CallersFrames. It reduces cost of serialization on high-volume paths and keeps stack traces of stripped binaries small. The raw stack can be resolved later by `goerr symbolize` command with the same binary.
+//
+// Format with %+v and Stacks() always resolve stack traces.
+func SetDeferredSymbolization(enabled bool) {
+	deferredSymbolization.Store(enabled)
+}
+
+// DeferredSymbolization returns true if deferred symbolization is enabled.
+func DeferredSymbolization() bool {
+	return deferredSymbolization.Load()
+}
+
+// RawStack is unresolved stack trace. PCs are hex encoded program counters of call sites. Anchor is hex encoded runtime address of an internal function of goerr, which is used to compute the load offset of position independent executables.
+type RawStack struct {
+	BuildID string   `json:"build_id"`
+	Anchor  string   `json:"anchor"`
+	PCs     []string `json:"pcs"`
+}
+
+// RawStack returns unresolved stack trace of the error. It returns nil if the error has no stack trace, e.g. sentinel error.
+func (x *Error) RawStack() *RawStack {
+	if x.st == nil || len(*x.st) == 0 {
+		return nil
+	}
+
+	pcs := make([]string, len(*x.st))
+	for i, pc := range *x.st {
+		pcs[i] = "0x" + strconv.FormatUint(uint64(newFrame(pc).pc()), 16)
+	}
+
+	return &RawStack{
+		BuildID: buildID(),
+		Anchor:  "0x" + strconv.FormatUint(uint64(reflect.ValueOf(callers).Pointer()), 16),
+		PCs:     pcs,
+	}
+}
+
+var buildID = sync.OnceValue(func() string {
+	path, err := os.Executable()
+	if err != nil {
+		return ""
+	}
+	return buildid.ReadFile(path) // Build ID is available only for ELF binaries
+})
@@ -0,0 +1,63 @@
+package goerr_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/m-mizutani/goerr/v2"
+)
+
+func TestDeferredSymbolization(t *testing.T) {
+	goerr.SetDeferredSymbolization(true)
+	t.Cleanup(func() { goerr.SetDeferredSymbolization(false) })
+
+	err := goerr.Wrap(goerr.New("inner"), "outer")
+	p := err.Printable()
+	if p.StackTrace != nil || p.RawStack == nil || len(p.RawStack.PCs) == 0 {
+		t.Fatalf("Expected raw stack instead of stack trace: %+v", p)
+	}
+	if runtime.GOOS == "linux" && p.RawStack.BuildID == "" {
+		t.Error("Expected build ID on linux")
+	}
+
+	pc, parseErr := strconv.ParseUint(strings.TrimPrefix(p.RawStack.PCs[0], "0x"), 16, 64)
+	if parseErr != nil {
+		t.Fatal(parseErr)
+	}
+	if fn := runtime.FuncForPC(uintptr(pc)); fn == nil || fn.Name() != "github.com/m-mizutani/goerr/v2_test.TestDeferredSymbolization" {
+		t.Errorf("Unexpected function of raw PC: %v", fn)
+	}
+
+	cause, ok := p.Cause.(*goerr.Printable)
+	if !ok || cause.RawStack == nil {
+		t.Errorf("Expected raw stack in cause: %+v", p.Cause)
+	}
+
+	// JSON and slog output
+	raw, _ := json.Marshal(err)
+	if !bytes.Contains(raw, []byte(`"raw_stack":{"build_id":`)) {
+		t.Errorf("Unexpected JSON: %s", raw)
+	}
+	var buf bytes.Buffer
+	slog.New(slog.NewJSONHandler(&buf, nil)).Error("failed", "error", err)
+	if !strings.Contains(buf.String(), `"raw_stack":{"build_id":`) || strings.Contains(buf.String(), "symbolize_test.go") {
+		t.Errorf("Unexpected slog output: %s", buf.String())
+	}
+
+	// Format always resolves stack trace
+	if !strings.Contains(fmt.Sprintf("%+v", err), "TestDeferredSymbolization") {
+		t.Error("Expected resolved stack trace in formatted output")
+	}
+}
+
+func TestRawStackSentinel(t *testing.T) {
+	if goerr.Sentinel("raw_stack", "no stack").RawStack() != nil {
+		t.Error("Sentinel error should not have raw stack")
+	}
+}
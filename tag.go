@@ -48,8 +48,11 @@ func (t tag) Format(s fmt.State, verb rune) {
 
 // WithTags adds tags to the error. The tags are used to categorize errors.
 //
-// Deprecated: Use the goerr.Tag option with goerr.New() or goerr.Wrap() instead.
+// Deprecated: WithTags modifies the error in place and is not safe for an error shared between goroutines. Use the goerr.Tag option with goerr.New() or goerr.Wrap(), or goerr.With(err, goerr.Tag(t)) for an existing error instead.
 func (x *Error) WithTags(tags ...tag) *Error {
+	if x.sentinel {
+		x = x.clone()
+	}
 	for _, tag := range tags {
 		x.tags[tag] = struct{}{}
 	}
@@ -72,3 +75,11 @@ func (t tags) clone() tags {
 	}
 	return newTags
 }
+
+func (t tags) list() []string {
+	tagList := make([]string, 0, len(t))
+	for tag := range t {
+		tagList = append(tagList, tag.value)
+	}
+	return tagList
+}
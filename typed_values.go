@@ -70,23 +70,18 @@ func GetTypedValue[T any](err error, key TypedKey[T]) (T, bool) {
 }
 
 func getTypedValueFromError[T any](err *Error, key TypedKey[T]) (T, bool) {
-	// Search in current error's typed values
-	if value, ok := err.typedValues[key.name]; ok {
-		// Key found at this level. This is the definitive value.
-		// Check if the type matches.
-		if typedValue, ok := value.(T); ok {
-			return typedValue, true
+	// Search from outermost error. The first level that has the key is definitive.
+	for _, e := range err.chain() {
+		value, ok := e.typedValues[key.name]
+		if !ok {
+			continue
 		}
-		// Type does not match. Do not search deeper for this key.
-		var zero T
-		return zero, false
-	}
 
-	// Key not found at this level. Search in wrapped errors recursively.
-	if cause := err.Unwrap(); cause != nil {
-		if wrappedErr := Unwrap(cause); wrappedErr != nil {
-			return getTypedValueFromError(wrappedErr, key)
+		// Check if the type matches. If not, do not search deeper for this key.
+		if typedValue, ok := value.(T); ok {
+			return typedValue, true
 		}
+		break
 	}
 
 	var zero T